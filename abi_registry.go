@@ -0,0 +1,209 @@
+package ethutils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Names of the ABIs shipped with ethutils. They are used to look the
+// ABIs up with GetBuiltinABI.
+const (
+	ERC20_ABI           string = "erc20"
+	ERC721_ABI          string = "erc721"
+	ERC1155_ABI         string = "erc1155"
+	WETH9_ABI           string = "weth9"
+	ERC4626_ABI         string = "erc4626"
+	ERC2612_ABI         string = "erc2612"
+	MULTICALL_ABI       string = "multicall"
+	MULTICALL3_ABI      string = "multicall3"
+	GNOSIS_SAFE_ABI     string = "gnosis-safe"
+	GNOSIS_MULTISIG_ABI string = "gnosis-multisig"
+)
+
+var builtinABIs = map[string]string{
+	ERC20_ABI:           erc20abi,
+	ERC721_ABI:          erc721abi,
+	ERC1155_ABI:         erc1155abi,
+	WETH9_ABI:           weth9abi,
+	ERC4626_ABI:         erc4626abi,
+	ERC2612_ABI:         erc2612abi,
+	MULTICALL_ABI:       multicallabi,
+	MULTICALL3_ABI:      multicall3abi,
+	GNOSIS_SAFE_ABI:     gnosissafeabi,
+	GNOSIS_MULTISIG_ABI: gnosismultisigabi,
+}
+
+// the order in which builtin ABIs are tried when looking up an ABI by
+// method or event id. ERC20 comes first so a plain token call is not
+// attributed to one of the ABIs extending it, such as ERC4626. The other
+// ABIs don't share selectors.
+var builtinABILookupOrder = []string{
+	ERC20_ABI,
+	GNOSIS_SAFE_ABI,
+	GNOSIS_MULTISIG_ABI,
+	MULTICALL3_ABI,
+	MULTICALL_ABI,
+	ERC4626_ABI,
+	ERC2612_ABI,
+	WETH9_ABI,
+	ERC1155_ABI,
+	ERC721_ABI,
+}
+
+// parsedABI is a builtin ABI parsed on first use
+type parsedABI struct {
+	once   sync.Once
+	result *abi.ABI
+	err    error
+}
+
+var parsedBuiltinABIs = func() map[string]*parsedABI {
+	result := map[string]*parsedABI{}
+	for name, _ := range builtinABIs {
+		result[name] = &parsedABI{}
+	}
+	return result
+}()
+
+// BuiltinABINames returns the names of all ABIs shipped with ethutils
+func BuiltinABINames() []string {
+	result := []string{}
+	for name, _ := range builtinABIs {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// GetBuiltinABIString returns the JSON of a builtin ABI by its name
+func GetBuiltinABIString(name string) (string, error) {
+	content, found := builtinABIs[strings.ToLower(name)]
+	if !found {
+		return "", fmt.Errorf("no builtin abi named %s", name)
+	}
+	return content, nil
+}
+
+// GetBuiltinABI returns a builtin ABI by its name. Every ABI is parsed
+// once and shared by all callers so it must not be modified.
+func GetBuiltinABI(name string) (*abi.ABI, error) {
+	content, err := GetBuiltinABIString(name)
+	if err != nil {
+		return nil, err
+	}
+	parsed := parsedBuiltinABIs[strings.ToLower(name)]
+	parsed.once.Do(func() {
+		result, err := abi.JSON(strings.NewReader(content))
+		if err != nil {
+			parsed.err = err
+			return
+		}
+		parsed.result = &result
+	})
+	return parsed.result, parsed.err
+}
+
+// FindBuiltinABIByMethod returns the first builtin ABI having a method
+// with the selector of the call data, together with its name
+func FindBuiltinABIByMethod(data []byte) (*abi.ABI, string, error) {
+	if len(data) < 4 {
+		return nil, "", fmt.Errorf("call data is too short to have a method id")
+	}
+	for _, name := range builtinABILookupOrder {
+		a, err := GetBuiltinABI(name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := a.MethodById(data[:4]); err == nil {
+			return a, name, nil
+		}
+	}
+	return nil, "", fmt.Errorf("no builtin abi has method id: %#x", data[:4])
+}
+
+// FindBuiltinABIByEvent returns the first builtin ABI having an event
+// that can decode l, together with its name. Events sharing a topic id,
+// such as the Transfer of ERC20 and ERC721 tokens, are told apart by
+// their number of indexed arguments and by their data.
+func FindBuiltinABIByEvent(l types.Log) (*abi.ABI, string, error) {
+	if len(l.Topics) == 0 {
+		return nil, "", fmt.Errorf("log has no topic to find its event")
+	}
+	for _, name := range builtinABILookupOrder {
+		a, err := GetBuiltinABI(name)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, event := range a.Events {
+			if event.ID == l.Topics[0] && eventMatchesLog(event, l) {
+				return a, name, nil
+			}
+		}
+	}
+	return nil, "", fmt.Errorf("no builtin abi has an event matching id %s with %d topics", l.Topics[0].Hex(), len(l.Topics))
+}
+
+// eventMatchesLog returns true if the topics of l are the indexed
+// arguments of event and its data unpacks to the other arguments
+func eventMatchesLog(event abi.Event, l types.Log) bool {
+	indexed := 0
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed += 1
+		}
+	}
+	if indexed != len(l.Topics)-1 {
+		return false
+	}
+	nonIndexed := event.Inputs.NonIndexed()
+	if len(nonIndexed) == 0 {
+		return len(l.Data) == 0
+	}
+	_, err := nonIndexed.UnpackValues(l.Data)
+	return err == nil
+}
+
+func GetERC721ABI() *abi.ABI {
+	result, _ := abi.JSON(strings.NewReader(erc721abi))
+	return &result
+}
+
+func GetERC1155ABI() *abi.ABI {
+	result, _ := abi.JSON(strings.NewReader(erc1155abi))
+	return &result
+}
+
+func GetWETH9ABI() *abi.ABI {
+	result, _ := abi.JSON(strings.NewReader(weth9abi))
+	return &result
+}
+
+func GetERC4626ABI() *abi.ABI {
+	result, _ := abi.JSON(strings.NewReader(erc4626abi))
+	return &result
+}
+
+func GetERC2612ABI() *abi.ABI {
+	result, _ := abi.JSON(strings.NewReader(erc2612abi))
+	return &result
+}
+
+func GetMultiCall3ABI() *abi.ABI {
+	result, _ := abi.JSON(strings.NewReader(multicall3abi))
+	return &result
+}
+
+func GetGnosisSafeABI() *abi.ABI {
+	result, _ := abi.JSON(strings.NewReader(gnosissafeabi))
+	return &result
+}
+
+func GetGnosisMultisigABI() *abi.ABI {
+	result, _ := abi.JSON(strings.NewReader(gnosismultisigabi))
+	return &result
+}
@@ -0,0 +1,91 @@
+package ethutils
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestFindBuiltinABIByEvent(t *testing.T) {
+	transfer := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	from := common.BytesToHash(common.HexToAddress("0x1111111111111111111111111111111111111111").Bytes())
+	to := common.BytesToHash(common.HexToAddress("0x2222222222222222222222222222222222222222").Bytes())
+	amount := common.BigToHash(big.NewInt(42))
+
+	nft := types.Log{Topics: []common.Hash{transfer, from, to, amount}}
+	_, name, err := FindBuiltinABIByEvent(nft)
+	if err != nil || name != ERC721_ABI {
+		t.Errorf("ERC721 Transfer resolved to %q, %v, want %q", name, err, ERC721_ABI)
+	}
+
+	token := types.Log{Topics: []common.Hash{transfer, from, to}, Data: amount.Bytes()}
+	a, name, err := FindBuiltinABIByEvent(token)
+	if err != nil {
+		t.Fatalf("ERC20 Transfer not resolved: %s", err)
+	}
+	if _, err := a.Events["Transfer"].Inputs.NonIndexed().UnpackValues(token.Data); err != nil {
+		t.Errorf("ERC20 Transfer resolved to %q which can't decode it: %s", name, err)
+	}
+
+	if _, _, err := FindBuiltinABIByEvent(types.Log{Topics: []common.Hash{transfer, from}}); err == nil {
+		t.Errorf("a Transfer log with 2 topics was resolved")
+	}
+	if _, _, err := FindBuiltinABIByEvent(types.Log{}); err == nil {
+		t.Errorf("a log without topics was resolved")
+	}
+}
+
+func TestFindBuiltinABIByMethod(t *testing.T) {
+	cases := []struct {
+		signature string
+		want      string
+	}{
+		// ERC20 methods are shared by the ABIs extending ERC20
+		{"transfer(address,uint256)", ERC20_ABI},
+		{"approve(address,uint256)", ERC20_ABI},
+		{"balanceOf(address)", ERC20_ABI},
+		{"deposit(uint256,address)", ERC4626_ABI},
+		{"permit(address,address,uint256,uint256,uint8,bytes32,bytes32)", ERC2612_ABI},
+		{"withdraw(uint256)", WETH9_ABI},
+		{"safeTransferFrom(address,address,uint256)", ERC721_ABI},
+		{"safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)", ERC1155_ABI},
+		{"aggregate3((address,bool,bytes)[])", MULTICALL3_ABI},
+		{"execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes)", GNOSIS_SAFE_ABI},
+		{"submitTransaction(address,uint256,bytes)", GNOSIS_MULTISIG_ABI},
+	}
+	for _, c := range cases {
+		data := append(crypto.Keccak256([]byte(c.signature))[:4], make([]byte, 64)...)
+		_, name, err := FindBuiltinABIByMethod(data)
+		if err != nil || name != c.want {
+			t.Errorf("%s resolved to %q, %v, want %q", c.signature, name, err, c.want)
+		}
+	}
+
+	if _, _, err := FindBuiltinABIByMethod(crypto.Keccak256([]byte("unknown()"))[:4]); err == nil {
+		t.Errorf("an unknown method was resolved")
+	}
+	if _, _, err := FindBuiltinABIByMethod([]byte{0xa9, 0x05}); err == nil {
+		t.Errorf("call data without a full selector was resolved")
+	}
+}
+
+func TestGetBuiltinABI(t *testing.T) {
+	for _, name := range BuiltinABINames() {
+		a, err := GetBuiltinABI(name)
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		again, _ := GetBuiltinABI(strings.ToUpper(name))
+		if again != a {
+			t.Errorf("%s was parsed twice", name)
+		}
+	}
+	if _, err := GetBuiltinABI("erc9999"); err == nil {
+		t.Errorf("an unknown abi was found")
+	}
+}
@@ -0,0 +1,137 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/tranvictor/ethutils"
+)
+
+// CallContractWithABIAccessListWithNonceAndPrice asks the nodes for the
+// access list of the call, attaches it to an access list (EIP-2930) tx
+// and broadcasts the signed tx. The gas limit is the gas estimated with
+// the access list attached plus extraGas.
+func (self *Account) CallContractWithABIAccessListWithNonceAndPrice(
+	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractWithABIAccessListWithNonceAndPriceContext(context.Background(), a, nonce, priceGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractWithABIAccessListWithNonceAndPriceContext(ctx context.Context,
+	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractWithABIAccessListWithNonceAndPriceContext(ctx,
+		a, nonce, priceGwei, extraGas, amount, caddr, function, params...)
+}
+
+// CallExactContractWithABIAccessListWithNonceAndPrice is
+// CallContractWithABIAccessListWithNonceAndPrice with value in wei.
+func (self *Account) CallExactContractWithABIAccessListWithNonceAndPrice(
+	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallExactContractWithABIAccessListWithNonceAndPriceContext(context.Background(), a, nonce, priceGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractWithABIAccessListWithNonceAndPriceContext(ctx context.Context,
+	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	caddr, err := self.reader.ResolveAddressContext(ctx, caddr)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	chainID, err := self.reader.ChainIDContext(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot get chain id: %s", err)
+	}
+	data, err := self.PackDataWithABI(a, function, params...)
+	if err != nil {
+		return nil, false, fmt.Errorf("Cannot pack the params: %s", err)
+	}
+	accessList, _, err := self.reader.CreateAccessListContext(ctx,
+		self.Address(), caddr, priceGwei, value, data)
+	if err != nil {
+		return nil, false, fmt.Errorf("Cannot create access list: %s", err)
+	}
+	gasLimit, err := self.reader.EstimateGasWithAccessListContext(ctx,
+		self.Address(), caddr, priceGwei, value, data, accessList)
+	if err != nil {
+		return nil, false, fmt.Errorf("Cannot estimate gas: %s", err)
+	}
+	tx = ethutils.BuildExactAccessListTx(chainID, nonce, caddr, value, gasLimit+extraGas, priceGwei, data, accessList)
+	return self.SignTxAndBroadcastContext(ctx, tx)
+}
+
+func (self *Account) CallContractWithABIAccessList(
+	a *abi.ABI, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractWithABIAccessListContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractWithABIAccessListContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractWithABIAccessListContext(ctx, a, extraGas, amount, caddr, function, params...)
+}
+
+// CallExactContractWithABIAccessList is CallContractWithABIAccessList
+// with value in wei.
+func (self *Account) CallExactContractWithABIAccessList(
+	a *abi.ABI, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallExactContractWithABIAccessListContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractWithABIAccessListContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot get nonce: %s", err)
+	}
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot get recommended gas price: %s", err)
+	}
+	return self.CallExactContractWithABIAccessListWithNonceAndPriceContext(ctx,
+		a, nonce, priceGwei, extraGas, value, caddr, function, params...)
+}
+
+// CallContractWithABIStringAccessList is CallContractWithABIAccessList
+// with value as a decimal string of ETH such as "0.1" which is converted
+// exactly to wei.
+func (self *Account) CallContractWithABIStringAccessList(
+	a *abi.ABI, extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractWithABIStringAccessListContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractWithABIStringAccessListContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseEther(value)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractWithABIAccessListContext(ctx, a, extraGas, amount, caddr, function, params...)
+}
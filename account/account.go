@@ -1,6 +1,7 @@
 package account
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"strings"
@@ -17,6 +18,9 @@ import (
 	"github.com/tranvictor/ethutils/reader"
 )
 
+// Account signs txs and sends them through its reader and broadcaster.
+// Each method talking to the nodes has a Context variant, such as
+// SendETHContext, stopping the requests when ctx is done.
 type Account struct {
 	signer      Signer
 	reader      *reader.EthReader
@@ -76,19 +80,31 @@ func (self *Account) Address() string {
 }
 
 func (self *Account) GetMinedNonce() (uint64, error) {
-	return self.reader.GetMinedNonce(self.Address())
+	return self.GetMinedNonceContext(context.Background())
+}
+
+func (self *Account) GetMinedNonceContext(ctx context.Context) (uint64, error) {
+	return self.reader.GetMinedNonceContext(ctx, self.Address())
 }
 
 func (self *Account) GetPendingNonce() (uint64, error) {
-	return self.reader.GetPendingNonce(self.Address())
+	return self.GetPendingNonceContext(context.Background())
+}
+
+func (self *Account) GetPendingNonceContext(ctx context.Context) (uint64, error) {
+	return self.reader.GetPendingNonceContext(ctx, self.Address())
 }
 
 func (self *Account) ListOfPendingNonces() ([]uint64, error) {
-	minedNonce, err := self.GetMinedNonce()
+	return self.ListOfPendingNoncesContext(context.Background())
+}
+
+func (self *Account) ListOfPendingNoncesContext(ctx context.Context) ([]uint64, error) {
+	minedNonce, err := self.GetMinedNonceContext(ctx)
 	if err != nil {
 		return []uint64{}, err
 	}
-	pendingNonce, err := self.GetPendingNonce()
+	pendingNonce, err := self.GetPendingNonceContext(ctx)
 	if err != nil {
 		return []uint64{}, err
 	}
@@ -100,29 +116,49 @@ func (self *Account) ListOfPendingNonces() ([]uint64, error) {
 }
 
 func (self *Account) SendETHWithNonceAndPrice(nonce uint64, gasLimit uint64, priceGwei float64, ethAmount *big.Int, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.SendETHWithNonceAndPriceContext(context.Background(), nonce, gasLimit, priceGwei, ethAmount, to)
+}
+
+func (self *Account) SendETHWithNonceAndPriceContext(ctx context.Context, nonce uint64, gasLimit uint64, priceGwei float64, ethAmount *big.Int, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	to, err := self.reader.ResolveAddressContext(ctx, to)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot resolve address: %s", err)
+	}
 	tx = ethutils.BuildExactSendETHTx(nonce, to, ethAmount, gasLimit, priceGwei)
 	signedTx, err := self.signer.SignTx(tx)
 	if err != nil {
 		return tx, false, fmt.Errorf("couldn't sign the tx: %s", err)
 	}
-	_, broadcasted, errors = self.broadcaster.BroadcastTx(signedTx)
+	_, broadcasted, errors = self.broadcaster.BroadcastTxContext(ctx, signedTx)
 	return signedTx, broadcasted, errors
 }
 
 func (self *Account) ERC20Balance(tokenAddr string) (*big.Int, error) {
-	return self.reader.ERC20Balance(tokenAddr, self.Address())
+	return self.ERC20BalanceContext(context.Background(), tokenAddr)
+}
+
+func (self *Account) ERC20BalanceContext(ctx context.Context, tokenAddr string) (*big.Int, error) {
+	return self.reader.ERC20BalanceContext(ctx, tokenAddr, self.Address())
 }
 
 func (self *Account) ETHBalance() (*big.Int, error) {
-	return self.reader.GetBalance(self.Address())
+	return self.ETHBalanceContext(context.Background())
+}
+
+func (self *Account) ETHBalanceContext(ctx context.Context) (*big.Int, error) {
+	return self.reader.GetBalanceContext(ctx, self.Address())
 }
 
 func (self *Account) SendAllETHWithPrice(priceGwei float64, to string) (tx *types.Transaction, broadcasted bool, errors error) {
-	nonce, err := self.GetMinedNonce()
+	return self.SendAllETHWithPriceContext(context.Background(), priceGwei, to)
+}
+
+func (self *Account) SendAllETHWithPriceContext(ctx context.Context, priceGwei float64, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get nonce: %s", err)
 	}
-	balance, err := self.reader.GetBalance(self.Address())
+	balance, err := self.reader.GetBalanceContext(ctx, self.Address())
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get balance: %s", err)
 	}
@@ -130,19 +166,23 @@ func (self *Account) SendAllETHWithPrice(priceGwei float64, to string) (tx *type
 	if amount.Cmp(big.NewInt(0)) != 1 {
 		return nil, false, fmt.Errorf("not enough to do a tx with gas price: %f gwei", priceGwei)
 	}
-	return self.SendETHWithNonceAndPrice(nonce, 30000, priceGwei, amount, to)
+	return self.SendETHWithNonceAndPriceContext(ctx, nonce, 30000, priceGwei, amount, to)
 }
 
 func (self *Account) SendAllETH(to string) (tx *types.Transaction, broadcasted bool, errors error) {
-	nonce, err := self.GetMinedNonce()
+	return self.SendAllETHContext(context.Background(), to)
+}
+
+func (self *Account) SendAllETHContext(ctx context.Context, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get nonce: %s", err)
 	}
-	priceGwei, err := self.reader.RecommendedGasPrice()
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get recommended gas price: %s", err)
 	}
-	balance, err := self.reader.GetBalance(self.Address())
+	balance, err := self.reader.GetBalanceContext(ctx, self.Address())
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get balance: %s", err)
 	}
@@ -150,95 +190,274 @@ func (self *Account) SendAllETH(to string) (tx *types.Transaction, broadcasted b
 	if amount.Cmp(big.NewInt(0)) != 1 {
 		return nil, false, fmt.Errorf("not enough to do a tx with gas price: %f gwei", priceGwei)
 	}
-	return self.SendETHWithNonceAndPrice(nonce, 30000, priceGwei, amount, to)
+	return self.SendETHWithNonceAndPriceContext(ctx, nonce, 30000, priceGwei, amount, to)
 }
 
 func (self *Account) SetERC20Allowance(tokenAddr string, spender string, tokenAmount float64) (tx *types.Transaction, broadcasted bool, errors error) {
-	decimals, err := self.reader.ERC20Decimal(tokenAddr)
+	return self.SetERC20AllowanceContext(context.Background(), tokenAddr, spender, tokenAmount)
+}
+
+func (self *Account) SetERC20AllowanceContext(ctx context.Context, tokenAddr string, spender string, tokenAmount float64) (tx *types.Transaction, broadcasted bool, errors error) {
+	spender, err := self.reader.ResolveAddressContext(ctx, spender)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	decimals, err := self.reader.ERC20DecimalContext(ctx, tokenAddr)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get token decimal: %s", err)
 	}
-	amount := ethutils.FloatToBigInt(tokenAmount, decimals)
-	return self.CallContract(
+	amount, err := ethutils.ParseFloatAmount(tokenAmount, decimals)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot parse token amount: %s", err)
+	}
+	return self.CallContractContext(ctx,
 		150000, 0, tokenAddr, "approve",
 		ethutils.HexToAddress(spender), amount)
 }
 
+// SetExactERC20Allowance approves spender to spend amount of the token
+// in the token's smallest unit.
+func (self *Account) SetExactERC20Allowance(tokenAddr string, spender string, amount *big.Int) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.SetExactERC20AllowanceContext(context.Background(), tokenAddr, spender, amount)
+}
+
+func (self *Account) SetExactERC20AllowanceContext(ctx context.Context, tokenAddr string, spender string, amount *big.Int) (tx *types.Transaction, broadcasted bool, errors error) {
+	spender, err := self.reader.ResolveAddressContext(ctx, spender)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	return self.CallERC20ContractContext(ctx,
+		150000, 0, tokenAddr, "approve",
+		ethutils.HexToAddress(spender), amount)
+}
+
+// SetERC20AllowanceString approves spender to spend tokenAmount of the
+// token. tokenAmount is a decimal string such as "1.5" which is
+// converted exactly using the token decimals.
+func (self *Account) SetERC20AllowanceString(tokenAddr string, spender string, tokenAmount string) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.SetERC20AllowanceStringContext(context.Background(), tokenAddr, spender, tokenAmount)
+}
+
+func (self *Account) SetERC20AllowanceStringContext(ctx context.Context, tokenAddr string, spender string, tokenAmount string) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := self.erc20AmountFromString(ctx, tokenAddr, tokenAmount)
+	if err != nil {
+		return nil, false, err
+	}
+	return self.SetExactERC20AllowanceContext(ctx, tokenAddr, spender, amount)
+}
+
+func (self *Account) erc20AmountFromString(ctx context.Context, tokenAddr string, tokenAmount string) (*big.Int, error) {
+	decimals, err := self.reader.ERC20DecimalContext(ctx, tokenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get token decimal: %s", err)
+	}
+	amount, err := ethutils.ParseUnits(tokenAmount, decimals)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse token amount: %s", err)
+	}
+	return amount, nil
+}
+
 func (self *Account) SendAllERC20(tokenAddr string, to string) (tx *types.Transaction, broadcasted bool, errors error) {
-	balance, err := self.ERC20Balance(tokenAddr)
+	return self.SendAllERC20Context(context.Background(), tokenAddr, to)
+}
+
+func (self *Account) SendAllERC20Context(ctx context.Context, tokenAddr string, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	to, err := self.reader.ResolveAddressContext(ctx, to)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	balance, err := self.ERC20BalanceContext(ctx, tokenAddr)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get token balance: %s", err)
 	}
-	return self.CallERC20Contract(150000, 0, tokenAddr, "transfer", ethutils.HexToAddress(to), balance)
+	return self.CallERC20ContractContext(ctx, 150000, 0, tokenAddr, "transfer", ethutils.HexToAddress(to), balance)
 }
 
 func (self *Account) SendERC20(tokenAddr string, tokenAmount float64, to string) (tx *types.Transaction, broadcasted bool, errors error) {
-	decimals, err := self.reader.ERC20Decimal(tokenAddr)
+	return self.SendERC20Context(context.Background(), tokenAddr, tokenAmount, to)
+}
+
+func (self *Account) SendERC20Context(ctx context.Context, tokenAddr string, tokenAmount float64, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	to, err := self.reader.ResolveAddressContext(ctx, to)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	decimals, err := self.reader.ERC20DecimalContext(ctx, tokenAddr)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get token decimal: %s", err)
 	}
-	amount := ethutils.FloatToBigInt(tokenAmount, decimals)
-	return self.CallERC20Contract(150000, 0, tokenAddr, "transfer", ethutils.HexToAddress(to), amount)
+	amount, err := ethutils.ParseFloatAmount(tokenAmount, decimals)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot parse token amount: %s", err)
+	}
+	return self.CallERC20ContractContext(ctx, 150000, 0, tokenAddr, "transfer", ethutils.HexToAddress(to), amount)
 }
 
 func (self *Account) SendETH(ethAmount float64, to string) (tx *types.Transaction, broadcasted bool, errors error) {
-	nonce, err := self.GetMinedNonce()
+	return self.SendETHContext(context.Background(), ethAmount, to)
+}
+
+func (self *Account) SendETHContext(ctx context.Context, ethAmount float64, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot get nonce: %s", err)
+	}
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot get recommended gas price: %s", err)
+	}
+	amount, err := ethutils.ParseFloatAmount(ethAmount, 18)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot parse eth amount: %s", err)
+	}
+	return self.SendETHWithNonceAndPriceContext(ctx, nonce, 30000, priceGwei, amount, to)
+}
+
+// SendExactERC20 transfers amount of the token in the token's smallest
+// unit to the receiver.
+func (self *Account) SendExactERC20(tokenAddr string, amount *big.Int, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.SendExactERC20Context(context.Background(), tokenAddr, amount, to)
+}
+
+func (self *Account) SendExactERC20Context(ctx context.Context, tokenAddr string, amount *big.Int, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	to, err := self.reader.ResolveAddressContext(ctx, to)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	return self.CallERC20ContractContext(ctx, 150000, 0, tokenAddr, "transfer", ethutils.HexToAddress(to), amount)
+}
+
+// SendERC20String transfers tokenAmount of the token to the receiver.
+// tokenAmount is a decimal string such as "1.5" which is converted
+// exactly using the token decimals.
+func (self *Account) SendERC20String(tokenAddr string, tokenAmount string, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.SendERC20StringContext(context.Background(), tokenAddr, tokenAmount, to)
+}
+
+func (self *Account) SendERC20StringContext(ctx context.Context, tokenAddr string, tokenAmount string, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := self.erc20AmountFromString(ctx, tokenAddr, tokenAmount)
+	if err != nil {
+		return nil, false, err
+	}
+	return self.SendExactERC20Context(ctx, tokenAddr, amount, to)
+}
+
+// SendExactETH sends amount wei to the receiver.
+func (self *Account) SendExactETH(amount *big.Int, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.SendExactETHContext(context.Background(), amount, to)
+}
+
+func (self *Account) SendExactETHContext(ctx context.Context, amount *big.Int, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get nonce: %s", err)
 	}
-	priceGwei, err := self.reader.RecommendedGasPrice()
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get recommended gas price: %s", err)
 	}
-	amount := ethutils.FloatToBigInt(ethAmount, 18)
-	return self.SendETHWithNonceAndPrice(nonce, 30000, priceGwei, amount, to)
+	return self.SendETHWithNonceAndPriceContext(ctx, nonce, 30000, priceGwei, amount, to)
+}
+
+// SendETHString sends ethAmount ETH to the receiver. ethAmount is a
+// decimal string such as "0.1" which is converted exactly to wei.
+func (self *Account) SendETHString(ethAmount string, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.SendETHStringContext(context.Background(), ethAmount, to)
+}
+
+func (self *Account) SendETHStringContext(ctx context.Context, ethAmount string, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseEther(ethAmount)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot parse eth amount: %s", err)
+	}
+	return self.SendExactETHContext(ctx, amount, to)
 }
 
 func (self *Account) SendETHToMultipleAddressesWithPrice(priceGwei float64, amounts []float64, addresses []string) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
-	if len(amounts) != len(addresses) {
-		panic("amounts and addresses must have the same length")
-		return
+	return self.SendETHToMultipleAddressesWithPriceContext(context.Background(), priceGwei, amounts, addresses)
+}
+
+func (self *Account) SendETHToMultipleAddressesWithPriceContext(ctx context.Context, priceGwei float64, amounts []float64, addresses []string) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
+	exactAmounts, err := floatsToWei(amounts)
+	if err != nil {
+		return notSent(len(addresses), err)
 	}
-	nonce, err := self.GetMinedNonce()
+	return self.SendExactETHToMultipleAddressesWithPriceContext(ctx, priceGwei, exactAmounts, addresses)
+}
+
+func (self *Account) SendETHToMultipleAddresses(amounts []float64, addresses []string) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
+	return self.SendETHToMultipleAddressesContext(context.Background(), amounts, addresses)
+}
+
+func (self *Account) SendETHToMultipleAddressesContext(ctx context.Context, amounts []float64, addresses []string) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
+	exactAmounts, err := floatsToWei(amounts)
 	if err != nil {
-		panic(fmt.Errorf("cannot get nonce: %s", err))
-		return
+		return notSent(len(addresses), err)
 	}
-	txs = []*types.Transaction{}
-	broadcasteds = []bool{}
-	errors = []error{}
-	for i, addr := range addresses {
-		amount := amounts[i]
-		newNonce := nonce + uint64(i)
-		tx, broadcasted, e := self.SendETHWithNonceAndPrice(newNonce, 30000, priceGwei, ethutils.FloatToBigInt(amount, 18), addr)
-		txs = append(txs, tx)
-		broadcasteds = append(broadcasteds, broadcasted)
-		errors = append(errors, e)
+	return self.SendExactETHToMultipleAddressesContext(ctx, exactAmounts, addresses)
+}
+
+func floatsToWei(amounts []float64) ([]*big.Int, error) {
+	result := []*big.Int{}
+	for _, amount := range amounts {
+		wei, err := ethutils.ParseFloatAmount(amount, 18)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse eth amount: %s", err)
+		}
+		result = append(result, wei)
+	}
+	return result, nil
+}
+
+// notSent returns the results of sending to count addresses when err
+// prevented sending any tx, every address gets err as its error
+func notSent(count int, err error) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
+	txs = make([]*types.Transaction, count)
+	broadcasteds = make([]bool, count)
+	errors = make([]error, count)
+	for i := range errors {
+		errors[i] = err
 	}
 	return txs, broadcasteds, errors
 }
 
-func (self *Account) SendETHToMultipleAddresses(amounts []float64, addresses []string) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
+// SendExactETHToMultipleAddressesWithPrice sends amounts[i] wei to
+// addresses[i] with consecutive nonces. It returns a tx, a broadcasted
+// flag and an error per address. Invalid input is reported as the error
+// of every address without sending any tx.
+func (self *Account) SendExactETHToMultipleAddressesWithPrice(priceGwei float64, amounts []*big.Int, addresses []string) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
+	return self.SendExactETHToMultipleAddressesWithPriceContext(context.Background(), priceGwei, amounts, addresses)
+}
+
+func (self *Account) SendExactETHToMultipleAddressesWithPriceContext(ctx context.Context, priceGwei float64, amounts []*big.Int, addresses []string) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
 	if len(amounts) != len(addresses) {
-		panic("amounts and addresses must have the same length")
-		return
+		return notSent(len(addresses), fmt.Errorf("amounts and addresses must have the same length"))
 	}
-	nonce, err := self.GetMinedNonce()
-	if err != nil {
-		panic(fmt.Errorf("cannot get nonce: %s", err))
-		return
+	for i, amount := range amounts {
+		if amount == nil || amount.Sign() < 0 {
+			return notSent(len(addresses), fmt.Errorf("amount %d must be non-negative", i))
+		}
 	}
-	priceGwei, err := self.reader.RecommendedGasPrice()
+	resolved := []string{}
+	for _, addr := range addresses {
+		r, err := self.reader.ResolveAddressContext(ctx, addr)
+		if err != nil {
+			return notSent(len(addresses), fmt.Errorf("cannot resolve address: %s", err))
+		}
+		resolved = append(resolved, r)
+	}
+	addresses = resolved
+	nonce, err := self.GetMinedNonceContext(ctx)
 	if err != nil {
-		panic(fmt.Errorf("cannot get recommended gas price: %s", err))
+		return notSent(len(addresses), fmt.Errorf("cannot get nonce: %s", err))
 	}
 	txs = []*types.Transaction{}
 	broadcasteds = []bool{}
 	errors = []error{}
 	for i, addr := range addresses {
-		amount := amounts[i]
 		newNonce := nonce + uint64(i)
-		tx, broadcasted, e := self.SendETHWithNonceAndPrice(newNonce, 30000, priceGwei, ethutils.FloatToBigInt(amount, 18), addr)
+		tx, broadcasted, e := self.SendETHWithNonceAndPriceContext(ctx, newNonce, 30000, priceGwei, amounts[i], addr)
 		txs = append(txs, tx)
 		broadcasteds = append(broadcasteds, broadcasted)
 		errors = append(errors, e)
@@ -246,25 +465,67 @@ func (self *Account) SendETHToMultipleAddresses(amounts []float64, addresses []s
 	return txs, broadcasteds, errors
 }
 
+func (self *Account) SendExactETHToMultipleAddresses(amounts []*big.Int, addresses []string) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
+	return self.SendExactETHToMultipleAddressesContext(context.Background(), amounts, addresses)
+}
+
+func (self *Account) SendExactETHToMultipleAddressesContext(ctx context.Context, amounts []*big.Int, addresses []string) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
+	if err != nil {
+		return notSent(len(addresses), fmt.Errorf("cannot get recommended gas price: %s", err))
+	}
+	return self.SendExactETHToMultipleAddressesWithPriceContext(ctx, priceGwei, amounts, addresses)
+}
+
+// SendETHStringToMultipleAddresses sends amounts[i] ETH, given as
+// decimal strings, to addresses[i] with consecutive nonces.
+func (self *Account) SendETHStringToMultipleAddresses(amounts []string, addresses []string) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
+	return self.SendETHStringToMultipleAddressesContext(context.Background(), amounts, addresses)
+}
+
+func (self *Account) SendETHStringToMultipleAddressesContext(ctx context.Context, amounts []string, addresses []string) (txs []*types.Transaction, broadcasteds []bool, errors []error) {
+	exactAmounts := []*big.Int{}
+	for _, amount := range amounts {
+		exactAmount, err := ethutils.ParseEther(amount)
+		if err != nil {
+			return notSent(len(addresses), fmt.Errorf("cannot parse eth amount: %s", err))
+		}
+		exactAmounts = append(exactAmounts, exactAmount)
+	}
+	return self.SendExactETHToMultipleAddressesContext(ctx, exactAmounts, addresses)
+}
+
 func (self *Account) CallERC20ContractWithPrice(
 	priceGwei float64, extraGas uint64, value float64, caddr string, function string,
 	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
-	nonce, err := self.GetMinedNonce()
+	return self.CallERC20ContractWithPriceContext(context.Background(), priceGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallERC20ContractWithPriceContext(ctx context.Context,
+	priceGwei float64, extraGas uint64, value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get nonce: %s", err)
 	}
-	return self.CallERC20ContractWithNonceAndPrice(
+	return self.CallERC20ContractWithNonceAndPriceContext(ctx,
 		nonce, priceGwei, extraGas, value, caddr, function, params...)
 }
 
 func (self *Account) CallContractWithPrice(
 	priceGwei float64, extraGas uint64, value float64, caddr string, function string,
 	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
-	nonce, err := self.GetMinedNonce()
+	return self.CallContractWithPriceContext(context.Background(), priceGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractWithPriceContext(ctx context.Context,
+	priceGwei float64, extraGas uint64, value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get nonce: %s", err)
 	}
-	return self.CallContractWithNonceAndPrice(
+	return self.CallContractWithNonceAndPriceContext(ctx,
 		nonce, priceGwei, extraGas, value, caddr, function, params...)
 }
 
@@ -272,15 +533,22 @@ func (self *Account) CallERC20Contract(
 	extraGas uint64,
 	value float64, caddr string, function string,
 	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
-	nonce, err := self.GetMinedNonce()
+	return self.CallERC20ContractContext(context.Background(), extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallERC20ContractContext(ctx context.Context,
+	extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get nonce: %s", err)
 	}
-	priceGwei, err := self.reader.RecommendedGasPrice()
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get recommended gas price: %s", err)
 	}
-	return self.CallERC20ContractWithNonceAndPrice(
+	return self.CallERC20ContractWithNonceAndPriceContext(ctx,
 		nonce, priceGwei, extraGas, value, caddr, function, params...)
 }
 
@@ -288,18 +556,64 @@ func (self *Account) CallContract(
 	extraGas uint64,
 	value float64, caddr string, function string,
 	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
-	nonce, err := self.GetMinedNonce()
+	return self.CallContractContext(context.Background(), extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractContext(ctx context.Context,
+	extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractContext(ctx, extraGas, amount, caddr, function, params...)
+}
+
+// CallExactContract is CallContract with value in wei.
+func (self *Account) CallExactContract(
+	extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallExactContractContext(context.Background(), extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractContext(ctx context.Context,
+	extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get nonce: %s", err)
 	}
-	priceGwei, err := self.reader.RecommendedGasPrice()
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get recommended gas price: %s", err)
 	}
-	return self.CallContractWithNonceAndPrice(
+	return self.CallExactContractWithNonceAndPriceContext(ctx,
 		nonce, priceGwei, extraGas, value, caddr, function, params...)
 }
 
+// CallContractString is CallContract with value as a decimal string of
+// ETH such as "0.1" which is converted exactly to wei.
+func (self *Account) CallContractString(
+	extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractStringContext(context.Background(), extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractStringContext(ctx context.Context,
+	extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseEther(value)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractContext(ctx, extraGas, amount, caddr, function, params...)
+}
+
 func (self *Account) PackERC20Data(function string, params ...interface{}) ([]byte, error) {
 	abi := ethutils.GetERC20ABI()
 	return abi.Pack(function, params...)
@@ -321,14 +635,25 @@ func (self *Account) CallERC20ContractWithNonceAndPrice(
 	nonce uint64, priceGwei float64, extraGas uint64,
 	value float64, caddr string, function string,
 	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
-	if value < 0 {
-		panic("value must be non-negative")
+	return self.CallERC20ContractWithNonceAndPriceContext(context.Background(), nonce, priceGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallERC20ContractWithNonceAndPriceContext(ctx context.Context,
+	nonce uint64, priceGwei float64, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	caddr, err := self.reader.ResolveAddressContext(ctx, caddr)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	if _, err := ethutils.ParseFloatAmount(value, 18); err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
 	}
 	data, err := self.PackERC20Data(function, params...)
 	if err != nil {
 		return nil, false, fmt.Errorf("Cannot pack the params: %s", err)
 	}
-	gasLimit, err := self.reader.EstimateGas(
+	gasLimit, err := self.reader.EstimateGasContext(ctx,
 		self.Address(), caddr, priceGwei, value, data)
 	if err != nil {
 		return nil, false, fmt.Errorf("Cannot estimate gas: %s", err)
@@ -339,22 +664,28 @@ func (self *Account) CallERC20ContractWithNonceAndPrice(
 	if err != nil {
 		return tx, false, fmt.Errorf("couldn't sign the tx: %s", err)
 	}
-	_, broadcasted, errors = self.broadcaster.BroadcastTx(signedTx)
+	_, broadcasted, errors = self.broadcaster.BroadcastTxContext(ctx, signedTx)
 	return signedTx, broadcasted, errors
 }
 
 func (self *Account) DeployContract(
 	extraGas uint64, value float64, abiJson string, bytecode []byte,
 	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
-	nonce, err := self.GetMinedNonce()
+	return self.DeployContractContext(context.Background(), extraGas, value, abiJson, bytecode, params...)
+}
+
+func (self *Account) DeployContractContext(ctx context.Context,
+	extraGas uint64, value float64, abiJson string, bytecode []byte,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
 	if err != nil {
 		return nil, false, common.Address{}, fmt.Errorf("cannot get nonce: %s", err)
 	}
-	priceGwei, err := self.reader.RecommendedGasPrice()
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
 	if err != nil {
 		return nil, false, common.Address{}, fmt.Errorf("cannot get recommended gas price: %s", err)
 	}
-	return self.DeployContractWithNonceAndPrice(
+	return self.DeployContractWithNonceAndPriceContext(ctx,
 		nonce, priceGwei, extraGas, value, abiJson, bytecode,
 		params...)
 }
@@ -363,6 +694,17 @@ func (self *Account) DeployContractWithNonceAndPrice(
 	nonce uint64, priceGwei float64, extraGas uint64,
 	value float64, abiJson string, bytecode []byte,
 	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
+	return self.DeployContractWithNonceAndPriceContext(context.Background(), nonce, priceGwei, extraGas, value, abiJson, bytecode, params...)
+}
+
+func (self *Account) DeployContractWithNonceAndPriceContext(ctx context.Context,
+	nonce uint64, priceGwei float64, extraGas uint64,
+	value float64, abiJson string, bytecode []byte,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, false, common.Address{}, fmt.Errorf("invalid value: %s", err)
+	}
 	a, err := abi.JSON(strings.NewReader(abiJson))
 	if err != nil {
 		return nil, false, common.Address{}, err
@@ -374,20 +716,19 @@ func (self *Account) DeployContractWithNonceAndPrice(
 	fmt.Printf("Constructor abi encoding: %s\n", hexutil.Encode(input))
 	data := append(bytecode, input...)
 
-	gasLimit, err := self.reader.EstimateGas(
+	gasLimit, err := self.reader.EstimateGasContext(ctx,
 		self.Address(), "", priceGwei, value, data)
 	if err != nil {
 		return nil, false, common.Address{}, fmt.Errorf("Cannot estimate gas: %s", err)
 	}
 	gasLimit += extraGas
 
-	amount := ethutils.FloatToBigInt(value, 18)
 	tx = ethutils.BuildContractCreationTx(nonce, amount, gasLimit, priceGwei, data)
 	signedTx, err := self.signer.SignTx(tx)
 	if err != nil {
 		return tx, false, common.Address{}, fmt.Errorf("couldn't sign the tx: %s", err)
 	}
-	_, broadcasted, errors = self.broadcaster.BroadcastTx(signedTx)
+	_, broadcasted, errors = self.broadcaster.BroadcastTxContext(ctx, signedTx)
 	caddr = crypto.CreateAddress(self.address, tx.Nonce())
 	return signedTx, broadcasted, caddr, errors
 }
@@ -396,60 +737,152 @@ func (self *Account) CallContractWithABI(
 	a *abi.ABI, extraGas uint64,
 	value float64, caddr string, function string,
 	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractWithABIContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
 
-	nonce, err := self.GetMinedNonce()
+func (self *Account) CallContractWithABIContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractWithABIContext(ctx, a, extraGas, amount, caddr, function, params...)
+}
+
+// CallExactContractWithABI is CallContractWithABI with value in wei.
+func (self *Account) CallExactContractWithABI(
+	a *abi.ABI, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallExactContractWithABIContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractWithABIContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get nonce: %s", err)
 	}
-	priceGwei, err := self.reader.RecommendedGasPrice()
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("cannot get recommended gas price: %s", err)
 	}
-	return self.CallContractWithABINonceAndPrice(
+	return self.CallExactContractWithABINonceAndPriceContext(ctx,
 		a, nonce, priceGwei, extraGas, value, caddr, function, params...)
 }
 
+// CallContractWithABIString is CallContractWithABI with value as a
+// decimal string of ETH such as "0.1" which is converted exactly to wei.
+func (self *Account) CallContractWithABIString(
+	a *abi.ABI, extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractWithABIStringContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractWithABIStringContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseEther(value)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractWithABIContext(ctx, a, extraGas, amount, caddr, function, params...)
+}
+
 func (self *Account) CallContractWithABINonceAndPrice(
 	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
 	value float64, caddr string, function string,
 	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractWithABINonceAndPriceContext(context.Background(), a, nonce, priceGwei, extraGas, value, caddr, function, params...)
+}
 
-	if value < 0 {
-		panic("value must be non-negative")
-	}
-	data, err := self.PackDataWithABI(a, function, params...)
+func (self *Account) CallContractWithABINonceAndPriceContext(ctx context.Context,
+	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
 	if err != nil {
-		return nil, false, fmt.Errorf("Cannot pack the params: %s", err)
+		return nil, false, fmt.Errorf("invalid value: %s", err)
 	}
-	gasLimit, err := self.reader.EstimateGas(
-		self.Address(), caddr, priceGwei, value, data)
+	return self.CallExactContractWithABINonceAndPriceContext(ctx,
+		a, nonce, priceGwei, extraGas, amount, caddr, function, params...)
+}
+
+// CallExactContractWithABINonceAndPrice is
+// CallContractWithABINonceAndPrice with value in wei.
+func (self *Account) CallExactContractWithABINonceAndPrice(
+	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallExactContractWithABINonceAndPriceContext(context.Background(), a, nonce, priceGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractWithABINonceAndPriceContext(ctx context.Context,
+	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	tx, err := self.BuildExactContractTxWithABINonceAndPriceContext(ctx,
+		a, nonce, priceGwei, extraGas, value, caddr, function, params...)
 	if err != nil {
-		return nil, false, fmt.Errorf("Cannot estimate gas: %s", err)
+		return nil, false, err
 	}
-	gasLimit += extraGas
-	tx = ethutils.BuildTx(nonce, caddr, value, gasLimit, priceGwei, data)
-	return self.SignTxAndBroadcast(tx)
+	return self.SignTxAndBroadcastContext(ctx, tx)
 }
 
 func (self *Account) CallContractWithNonceAndPrice(
 	nonce uint64, priceGwei float64, extraGas uint64,
 	value float64, caddr string, function string,
 	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
-	if value < 0 {
-		panic("value must be non-negative")
+	return self.CallContractWithNonceAndPriceContext(context.Background(), nonce, priceGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractWithNonceAndPriceContext(ctx context.Context,
+	nonce uint64, priceGwei float64, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractWithNonceAndPriceContext(ctx,
+		nonce, priceGwei, extraGas, amount, caddr, function, params...)
+}
+
+// CallExactContractWithNonceAndPrice is CallContractWithNonceAndPrice
+// with value in wei.
+func (self *Account) CallExactContractWithNonceAndPrice(
+	nonce uint64, priceGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallExactContractWithNonceAndPriceContext(context.Background(), nonce, priceGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractWithNonceAndPriceContext(ctx context.Context,
+	nonce uint64, priceGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	caddr, err := self.reader.ResolveAddressContext(ctx, caddr)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot resolve address: %s", err)
 	}
 	data, err := self.PackData(caddr, function, params...)
 	if err != nil {
 		return nil, false, fmt.Errorf("Cannot pack the params: %s", err)
 	}
-	gasLimit, err := self.reader.EstimateGas(
+	gasLimit, err := self.reader.EstimateExactGasContext(ctx,
 		self.Address(), caddr, priceGwei, value, data)
 	if err != nil {
 		return nil, false, fmt.Errorf("Cannot estimate gas: %s", err)
 	}
 	gasLimit += extraGas
-	tx = ethutils.BuildTx(nonce, caddr, value, gasLimit, priceGwei, data)
-	return self.SignTxAndBroadcast(tx)
+	tx = ethutils.BuildExactTx(nonce, caddr, value, gasLimit, priceGwei, data)
+	return self.SignTxAndBroadcastContext(ctx, tx)
 }
 
 func (self *Account) SignTx(tx *types.Transaction) (*types.Transaction, error) {
@@ -461,10 +894,14 @@ func (self *Account) SignTx(tx *types.Transaction) (*types.Transaction, error) {
 }
 
 func (self *Account) SignTxAndBroadcast(tx *types.Transaction) (*types.Transaction, bool, error) {
+	return self.SignTxAndBroadcastContext(context.Background(), tx)
+}
+
+func (self *Account) SignTxAndBroadcastContext(ctx context.Context, tx *types.Transaction) (*types.Transaction, bool, error) {
 	signedTx, err := self.SignTx(tx)
 	if err != nil {
 		return tx, false, err
 	}
-	_, broadcasted, err := self.broadcaster.BroadcastTx(signedTx)
+	_, broadcasted, err := self.broadcaster.BroadcastTxContext(ctx, signedTx)
 	return signedTx, broadcasted, err
 }
@@ -0,0 +1,95 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/tranvictor/ethutils"
+)
+
+// DeployContractCreate2WithNonceAndPrice deploys the contract through a
+// CREATE2 factory taking salt ‖ init code as call data, such as
+// ethutils.DETERMINISTIC_DEPLOYMENT_PROXY, so the contract gets the same
+// address on every chain the factory exists. caddr is the predicted
+// address. If code already exists at caddr, nothing is sent and tx is nil.
+func (self *Account) DeployContractCreate2WithNonceAndPrice(
+	nonce uint64, priceGwei float64, extraGas uint64,
+	value float64, factory string, salt common.Hash,
+	abiJson string, bytecode []byte,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
+	return self.DeployContractCreate2WithNonceAndPriceContext(context.Background(), nonce, priceGwei, extraGas, value, factory, salt, abiJson, bytecode, params...)
+}
+
+func (self *Account) DeployContractCreate2WithNonceAndPriceContext(ctx context.Context,
+	nonce uint64, priceGwei float64, extraGas uint64,
+	value float64, factory string, salt common.Hash,
+	abiJson string, bytecode []byte,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
+	if _, err := ethutils.ParseFloatAmount(value, 18); err != nil {
+		return nil, false, common.Address{}, fmt.Errorf("invalid value: %s", err)
+	}
+	factory, err := self.reader.ResolveAddressContext(ctx, factory)
+	if err != nil {
+		return nil, false, common.Address{}, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	initCode, err := ethutils.ContractInitCode(abiJson, bytecode, params...)
+	if err != nil {
+		return nil, false, common.Address{}, err
+	}
+	caddr = ethutils.Create2Address(factory, salt, initCode)
+
+	code, err := self.reader.GetCodeContext(ctx, caddr.Hex())
+	if err != nil {
+		return nil, false, caddr, fmt.Errorf("cannot get code at %s: %s", caddr.Hex(), err)
+	}
+	if len(code) > 0 {
+		return nil, false, caddr, nil
+	}
+	factoryCode, err := self.reader.GetCodeContext(ctx, factory)
+	if err != nil {
+		return nil, false, caddr, fmt.Errorf("cannot get code of the factory: %s", err)
+	}
+	if len(factoryCode) == 0 {
+		return nil, false, caddr, fmt.Errorf("factory %s is not deployed on this chain", factory)
+	}
+
+	data := ethutils.PackDeterministicDeploymentData(salt, initCode)
+	gasLimit, err := self.reader.EstimateGasContext(ctx,
+		self.Address(), factory, priceGwei, value, data)
+	if err != nil {
+		return nil, false, caddr, fmt.Errorf("Cannot estimate gas: %s", err)
+	}
+	gasLimit += extraGas
+	tx = ethutils.BuildTx(nonce, factory, value, gasLimit, priceGwei, data)
+	signedTx, broadcasted, errors := self.SignTxAndBroadcastContext(ctx, tx)
+	return signedTx, broadcasted, caddr, errors
+}
+
+// DeployContractCreate2 deploys the contract through
+// ethutils.DETERMINISTIC_DEPLOYMENT_PROXY with the recommended gas price
+func (self *Account) DeployContractCreate2(
+	extraGas uint64, value float64, salt common.Hash,
+	abiJson string, bytecode []byte,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
+	return self.DeployContractCreate2Context(context.Background(), extraGas, value, salt, abiJson, bytecode, params...)
+}
+
+func (self *Account) DeployContractCreate2Context(ctx context.Context,
+	extraGas uint64, value float64, salt common.Hash,
+	abiJson string, bytecode []byte,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
+	if err != nil {
+		return nil, false, common.Address{}, fmt.Errorf("cannot get nonce: %s", err)
+	}
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
+	if err != nil {
+		return nil, false, common.Address{}, fmt.Errorf("cannot get recommended gas price: %s", err)
+	}
+	return self.DeployContractCreate2WithNonceAndPriceContext(ctx,
+		nonce, priceGwei, extraGas, value,
+		ethutils.DETERMINISTIC_DEPLOYMENT_PROXY, salt,
+		abiJson, bytecode, params...)
+}
@@ -0,0 +1,398 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tranvictor/ethutils"
+)
+
+// This file contains the EIP-1559 (dynamic fee) counterparts of the
+// legacy gas price based methods in account.go. Instead of a single
+// gas price, they take a max priority fee (tipCapGwei) and a max fee
+// (feeCapGwei) per gas, both in gwei. The methods that don't take the
+// fees use the recommended fees in wei as is.
+
+func (self *Account) dynamicFeeTxParams(ctx context.Context) (chainID int64, nonce uint64, tipCap, feeCap *big.Int, err error) {
+	chainID, err = self.reader.ChainIDContext(ctx)
+	if err != nil {
+		return 0, 0, nil, nil, fmt.Errorf("cannot get chain id: %s", err)
+	}
+	nonce, err = self.GetMinedNonceContext(ctx)
+	if err != nil {
+		return 0, 0, nil, nil, fmt.Errorf("cannot get nonce: %s", err)
+	}
+	tipCap, feeCap, err = self.reader.RecommendedDynamicFeesWeiContext(ctx)
+	if err != nil {
+		return 0, 0, nil, nil, fmt.Errorf("cannot get recommended dynamic fees: %s", err)
+	}
+	return chainID, nonce, tipCap, feeCap, nil
+}
+
+// buildContractTxWithABIDynamicFee builds a dynamic fee contract call tx
+// with the fees in wei
+func (self *Account) buildContractTxWithABIDynamicFee(ctx context.Context,
+	chainID int64, a *abi.ABI, nonce uint64, tipCap, feeCap *big.Int, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	caddr, err := self.reader.ResolveAddressContext(ctx, caddr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	data, err := self.PackDataWithABI(a, function, params...)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot pack the params: %s", err)
+	}
+	// the gas price only matters to the estimation through the balance
+	// check so the gwei float is precise enough
+	gasLimit, err := self.reader.EstimateExactGasContext(ctx,
+		self.Address(), caddr, ethutils.BigToFloat(feeCap, 9), value, data)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot estimate gas: %s", err)
+	}
+	gasLimit += extraGas
+	return ethutils.BuildExactDynamicFeeTxWei(chainID, nonce, caddr, value, gasLimit, tipCap, feeCap, data), nil
+}
+
+// buildDeployContractDynamicFeeTx builds a dynamic fee contract creation
+// tx with the fees in wei
+func (self *Account) buildDeployContractDynamicFeeTx(ctx context.Context,
+	chainID int64, nonce uint64, tipCap, feeCap *big.Int, extraGas uint64,
+	value *big.Int, abiJson string, bytecode []byte,
+	params ...interface{}) (*types.Transaction, error) {
+	a, err := abi.JSON(strings.NewReader(abiJson))
+	if err != nil {
+		return nil, err
+	}
+	input, err := a.Pack("", params...)
+	if err != nil {
+		return nil, err
+	}
+	data := append(bytecode, input...)
+
+	gasLimit, err := self.reader.EstimateExactGasContext(ctx,
+		self.Address(), "", ethutils.BigToFloat(feeCap, 9), value, data)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot estimate gas: %s", err)
+	}
+	gasLimit += extraGas
+	return ethutils.BuildDynamicFeeContractCreationTxWei(chainID, nonce, value, gasLimit, tipCap, feeCap, data), nil
+}
+
+func (self *Account) SendETHDynamicFeeWithNonceAndFees(nonce uint64, gasLimit uint64, tipCapGwei, feeCapGwei float64, ethAmount *big.Int, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.SendETHDynamicFeeWithNonceAndFeesContext(context.Background(), nonce, gasLimit, tipCapGwei, feeCapGwei, ethAmount, to)
+}
+
+func (self *Account) SendETHDynamicFeeWithNonceAndFeesContext(ctx context.Context, nonce uint64, gasLimit uint64, tipCapGwei, feeCapGwei float64, ethAmount *big.Int, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	to, err := self.reader.ResolveAddressContext(ctx, to)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	chainID, err := self.reader.ChainIDContext(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot get chain id: %s", err)
+	}
+	tx = ethutils.BuildExactDynamicFeeSendETHTx(chainID, nonce, to, ethAmount, gasLimit, tipCapGwei, feeCapGwei)
+	return self.SignTxAndBroadcastContext(ctx, tx)
+}
+
+func (self *Account) SendETHDynamicFee(ethAmount float64, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.SendETHDynamicFeeContext(context.Background(), ethAmount, to)
+}
+
+func (self *Account) SendETHDynamicFeeContext(ctx context.Context, ethAmount float64, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseFloatAmount(ethAmount, 18)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot parse eth amount: %s", err)
+	}
+	return self.SendExactETHDynamicFeeContext(ctx, amount, to)
+}
+
+func (self *Account) SendExactETHDynamicFee(amount *big.Int, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.SendExactETHDynamicFeeContext(context.Background(), amount, to)
+}
+
+func (self *Account) SendExactETHDynamicFeeContext(ctx context.Context, amount *big.Int, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	tx, err := self.BuildExactSendETHDynamicFeeTxContext(ctx, amount, to)
+	if err != nil {
+		return nil, false, err
+	}
+	return self.SignTxAndBroadcastContext(ctx, tx)
+}
+
+func (self *Account) SendETHStringDynamicFee(ethAmount string, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.SendETHStringDynamicFeeContext(context.Background(), ethAmount, to)
+}
+
+func (self *Account) SendETHStringDynamicFeeContext(ctx context.Context, ethAmount string, to string) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseEther(ethAmount)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot parse eth amount: %s", err)
+	}
+	return self.SendExactETHDynamicFeeContext(ctx, amount, to)
+}
+
+func (self *Account) CallContractWithABIDynamicFeeWithNonceAndFees(
+	a *abi.ABI, nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractWithABIDynamicFeeWithNonceAndFeesContext(context.Background(), a, nonce, tipCapGwei, feeCapGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractWithABIDynamicFeeWithNonceAndFeesContext(ctx context.Context,
+	a *abi.ABI, nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractWithABIDynamicFeeWithNonceAndFeesContext(ctx,
+		a, nonce, tipCapGwei, feeCapGwei, extraGas, amount, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractWithABIDynamicFeeWithNonceAndFees(
+	a *abi.ABI, nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallExactContractWithABIDynamicFeeWithNonceAndFeesContext(context.Background(), a, nonce, tipCapGwei, feeCapGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractWithABIDynamicFeeWithNonceAndFeesContext(ctx context.Context,
+	a *abi.ABI, nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	tx, err := self.BuildExactContractTxWithABIDynamicFeeWithNonceAndFeesContext(ctx,
+		a, nonce, tipCapGwei, feeCapGwei, extraGas, value, caddr, function, params...)
+	if err != nil {
+		return nil, false, err
+	}
+	return self.SignTxAndBroadcastContext(ctx, tx)
+}
+
+func (self *Account) CallContractWithABIDynamicFee(
+	a *abi.ABI, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractWithABIDynamicFeeContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractWithABIDynamicFeeContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractWithABIDynamicFeeContext(ctx, a, extraGas, amount, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractWithABIDynamicFee(
+	a *abi.ABI, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallExactContractWithABIDynamicFeeContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractWithABIDynamicFeeContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	tx, err := self.BuildExactContractTxWithABIDynamicFeeContext(ctx,
+		a, extraGas, value, caddr, function, params...)
+	if err != nil {
+		return nil, false, err
+	}
+	return self.SignTxAndBroadcastContext(ctx, tx)
+}
+
+func (self *Account) CallContractWithABIStringDynamicFee(
+	a *abi.ABI, extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractWithABIStringDynamicFeeContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractWithABIStringDynamicFeeContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseEther(value)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractWithABIDynamicFeeContext(ctx, a, extraGas, amount, caddr, function, params...)
+}
+
+func (self *Account) CallContractDynamicFeeWithNonceAndFees(
+	nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractDynamicFeeWithNonceAndFeesContext(context.Background(), nonce, tipCapGwei, feeCapGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractDynamicFeeWithNonceAndFeesContext(ctx context.Context,
+	nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	a, err := self.reader.GetABI(caddr)
+	if err != nil {
+		return nil, false, fmt.Errorf("Cannot get ABI from scanner for %s", caddr)
+	}
+	return self.CallContractWithABIDynamicFeeWithNonceAndFeesContext(ctx,
+		a, nonce, tipCapGwei, feeCapGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractDynamicFeeWithNonceAndFees(
+	nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallExactContractDynamicFeeWithNonceAndFeesContext(context.Background(), nonce, tipCapGwei, feeCapGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractDynamicFeeWithNonceAndFeesContext(ctx context.Context,
+	nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	a, err := self.reader.GetABI(caddr)
+	if err != nil {
+		return nil, false, fmt.Errorf("Cannot get ABI from scanner for %s", caddr)
+	}
+	return self.CallExactContractWithABIDynamicFeeWithNonceAndFeesContext(ctx,
+		a, nonce, tipCapGwei, feeCapGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractDynamicFee(
+	extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractDynamicFeeContext(context.Background(), extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractDynamicFeeContext(ctx context.Context,
+	extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	a, err := self.reader.GetABI(caddr)
+	if err != nil {
+		return nil, false, fmt.Errorf("Cannot get ABI from scanner for %s", caddr)
+	}
+	return self.CallContractWithABIDynamicFeeContext(ctx,
+		a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractDynamicFee(
+	extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallExactContractDynamicFeeContext(context.Background(), extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallExactContractDynamicFeeContext(ctx context.Context,
+	extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	a, err := self.reader.GetABI(caddr)
+	if err != nil {
+		return nil, false, fmt.Errorf("Cannot get ABI from scanner for %s", caddr)
+	}
+	return self.CallExactContractWithABIDynamicFeeContext(ctx,
+		a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractStringDynamicFee(
+	extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractStringDynamicFeeContext(context.Background(), extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallContractStringDynamicFeeContext(ctx context.Context,
+	extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	amount, err := ethutils.ParseEther(value)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.CallExactContractDynamicFeeContext(ctx, extraGas, amount, caddr, function, params...)
+}
+
+func (self *Account) CallERC20ContractDynamicFee(
+	extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallERC20ContractDynamicFeeContext(context.Background(), extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) CallERC20ContractDynamicFeeContext(ctx context.Context,
+	extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, errors error) {
+	return self.CallContractWithABIDynamicFeeContext(ctx,
+		ethutils.GetERC20ABI(), extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) DeployContractDynamicFeeWithNonceAndFees(
+	nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value float64, abiJson string, bytecode []byte,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
+	return self.DeployContractDynamicFeeWithNonceAndFeesContext(context.Background(), nonce, tipCapGwei, feeCapGwei, extraGas, value, abiJson, bytecode, params...)
+}
+
+func (self *Account) DeployContractDynamicFeeWithNonceAndFeesContext(ctx context.Context,
+	nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value float64, abiJson string, bytecode []byte,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, false, common.Address{}, fmt.Errorf("invalid value: %s", err)
+	}
+	chainID, err := self.reader.ChainIDContext(ctx)
+	if err != nil {
+		return nil, false, common.Address{}, fmt.Errorf("cannot get chain id: %s", err)
+	}
+	tx, err = self.buildDeployContractDynamicFeeTx(ctx,
+		chainID, nonce, ethutils.GweiToWei(tipCapGwei), ethutils.GweiToWei(feeCapGwei), extraGas,
+		amount, abiJson, bytecode, params...)
+	if err != nil {
+		return nil, false, common.Address{}, err
+	}
+	signedTx, broadcasted, errors := self.SignTxAndBroadcastContext(ctx, tx)
+	caddr = crypto.CreateAddress(self.address, tx.Nonce())
+	return signedTx, broadcasted, caddr, errors
+}
+
+func (self *Account) DeployContractDynamicFee(
+	extraGas uint64, value float64, abiJson string, bytecode []byte,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
+	return self.DeployContractDynamicFeeContext(context.Background(), extraGas, value, abiJson, bytecode, params...)
+}
+
+func (self *Account) DeployContractDynamicFeeContext(ctx context.Context,
+	extraGas uint64, value float64, abiJson string, bytecode []byte,
+	params ...interface{}) (tx *types.Transaction, broadcasted bool, caddr common.Address, errors error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, false, common.Address{}, fmt.Errorf("invalid value: %s", err)
+	}
+	chainID, nonce, tipCap, feeCap, err := self.dynamicFeeTxParams(ctx)
+	if err != nil {
+		return nil, false, common.Address{}, err
+	}
+	tx, err = self.buildDeployContractDynamicFeeTx(ctx,
+		chainID, nonce, tipCap, feeCap, extraGas, amount, abiJson, bytecode, params...)
+	if err != nil {
+		return nil, false, common.Address{}, err
+	}
+	signedTx, broadcasted, errors := self.SignTxAndBroadcastContext(ctx, tx)
+	caddr = crypto.CreateAddress(self.address, tx.Nonce())
+	return signedTx, broadcasted, caddr, errors
+}
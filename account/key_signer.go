@@ -4,9 +4,7 @@ import (
 	"crypto/ecdsa"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type KeySigner struct {
@@ -14,12 +12,10 @@ type KeySigner struct {
 	key     *ecdsa.PrivateKey
 }
 
+// SignTx signs legacy, access list and dynamic fee txs using the
+// London signer of the configured chain.
 func (self *KeySigner) SignTx(tx *types.Transaction) (*types.Transaction, error) {
-	opts, err := bind.NewKeyedTransactorWithChainID(self.key, big.NewInt(self.chainID))
-	if err != nil {
-		return nil, err
-	}
-	return opts.Signer(crypto.PubkeyToAddress(self.key.PublicKey), tx)
+	return types.SignTx(tx, types.NewLondonSigner(big.NewInt(self.chainID)), self.key)
 }
 
 func NewKeySigner(key *ecdsa.PrivateKey, chainID int64) *KeySigner {
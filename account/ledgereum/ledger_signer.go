@@ -51,6 +51,9 @@ func (self *LedgerSigner) Unlock() error {
 }
 
 func (self *LedgerSigner) SignTx(tx *types.Transaction) (*types.Transaction, error) {
+	if tx.Type() != types.LegacyTxType {
+		return tx, fmt.Errorf("ledger only supports signing legacy txs, got tx type %d", tx.Type())
+	}
 	self.mu.Lock()
 	defer self.mu.Unlock()
 	fmt.Printf("Going to proceed signing procedure\n")
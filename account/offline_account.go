@@ -0,0 +1,252 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/tranvictor/ethutils"
+)
+
+// BuildContractTxWithABINonceAndPrice builds the contract call tx without
+// signing it so it can be exported with ExportUnsignedTx and signed on
+// an offline machine.
+func (self *Account) BuildContractTxWithABINonceAndPrice(
+	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	return self.BuildContractTxWithABINonceAndPriceContext(context.Background(), a, nonce, priceGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) BuildContractTxWithABINonceAndPriceContext(ctx context.Context,
+	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.BuildExactContractTxWithABINonceAndPriceContext(ctx,
+		a, nonce, priceGwei, extraGas, amount, caddr, function, params...)
+}
+
+// BuildExactContractTxWithABINonceAndPrice is
+// BuildContractTxWithABINonceAndPrice with value in wei.
+func (self *Account) BuildExactContractTxWithABINonceAndPrice(
+	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	return self.BuildExactContractTxWithABINonceAndPriceContext(context.Background(), a, nonce, priceGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) BuildExactContractTxWithABINonceAndPriceContext(ctx context.Context,
+	a *abi.ABI, nonce uint64, priceGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	caddr, err := self.reader.ResolveAddressContext(ctx, caddr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	data, err := self.PackDataWithABI(a, function, params...)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot pack the params: %s", err)
+	}
+	gasLimit, err := self.reader.EstimateExactGasContext(ctx,
+		self.Address(), caddr, priceGwei, value, data)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot estimate gas: %s", err)
+	}
+	gasLimit += extraGas
+	return ethutils.BuildExactTx(nonce, caddr, value, gasLimit, priceGwei, data), nil
+}
+
+func (self *Account) BuildContractTxWithABI(
+	a *abi.ABI, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	return self.BuildContractTxWithABIContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) BuildContractTxWithABIContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value float64, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	amount, err := ethutils.ParseFloatAmount(value, 18)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.BuildExactContractTxWithABIContext(ctx, a, extraGas, amount, caddr, function, params...)
+}
+
+// BuildExactContractTxWithABI is BuildContractTxWithABI with value in
+// wei.
+func (self *Account) BuildExactContractTxWithABI(
+	a *abi.ABI, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	return self.BuildExactContractTxWithABIContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) BuildExactContractTxWithABIContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	nonce, err := self.GetMinedNonceContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get nonce: %s", err)
+	}
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get recommended gas price: %s", err)
+	}
+	return self.BuildExactContractTxWithABINonceAndPriceContext(ctx,
+		a, nonce, priceGwei, extraGas, value, caddr, function, params...)
+}
+
+// BuildContractTxWithABIString is BuildContractTxWithABI with value as a
+// decimal string of ETH such as "0.1" which is converted exactly to wei.
+func (self *Account) BuildContractTxWithABIString(
+	a *abi.ABI, extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	return self.BuildContractTxWithABIStringContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) BuildContractTxWithABIStringContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value string, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	amount, err := ethutils.ParseEther(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %s", err)
+	}
+	return self.BuildExactContractTxWithABIContext(ctx, a, extraGas, amount, caddr, function, params...)
+}
+
+// BuildExactSendETHTx builds a tx sending amount wei to the receiver
+// without signing it.
+func (self *Account) BuildExactSendETHTx(amount *big.Int, to string) (*types.Transaction, error) {
+	return self.BuildExactSendETHTxContext(context.Background(), amount, to)
+}
+
+func (self *Account) BuildExactSendETHTxContext(ctx context.Context, amount *big.Int, to string) (*types.Transaction, error) {
+	to, err := self.reader.ResolveAddressContext(ctx, to)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	nonce, err := self.GetMinedNonceContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get nonce: %s", err)
+	}
+	priceGwei, err := self.reader.RecommendedGasPriceContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get recommended gas price: %s", err)
+	}
+	return ethutils.BuildExactSendETHTx(nonce, to, amount, 30000, priceGwei), nil
+}
+
+// BuildExactContractTxWithABIDynamicFeeWithNonceAndFees builds the
+// dynamic fee (EIP-1559) contract call tx without signing it so it can
+// be exported with ExportUnsignedTx.
+func (self *Account) BuildExactContractTxWithABIDynamicFeeWithNonceAndFees(
+	a *abi.ABI, nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	return self.BuildExactContractTxWithABIDynamicFeeWithNonceAndFeesContext(context.Background(), a, nonce, tipCapGwei, feeCapGwei, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) BuildExactContractTxWithABIDynamicFeeWithNonceAndFeesContext(ctx context.Context,
+	a *abi.ABI, nonce uint64, tipCapGwei, feeCapGwei float64, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	chainID, err := self.reader.ChainIDContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get chain id: %s", err)
+	}
+	return self.buildContractTxWithABIDynamicFee(ctx,
+		chainID, a, nonce, ethutils.GweiToWei(tipCapGwei), ethutils.GweiToWei(feeCapGwei), extraGas,
+		value, caddr, function, params...)
+}
+
+func (self *Account) BuildExactContractTxWithABIDynamicFee(
+	a *abi.ABI, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	return self.BuildExactContractTxWithABIDynamicFeeContext(context.Background(), a, extraGas, value, caddr, function, params...)
+}
+
+func (self *Account) BuildExactContractTxWithABIDynamicFeeContext(ctx context.Context,
+	a *abi.ABI, extraGas uint64,
+	value *big.Int, caddr string, function string,
+	params ...interface{}) (*types.Transaction, error) {
+	chainID, nonce, tipCap, feeCap, err := self.dynamicFeeTxParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return self.buildContractTxWithABIDynamicFee(ctx,
+		chainID, a, nonce, tipCap, feeCap, extraGas, value, caddr, function, params...)
+}
+
+// BuildExactSendETHDynamicFeeTx builds a dynamic fee (EIP-1559) tx
+// sending amount wei to the receiver without signing it.
+func (self *Account) BuildExactSendETHDynamicFeeTx(amount *big.Int, to string) (*types.Transaction, error) {
+	return self.BuildExactSendETHDynamicFeeTxContext(context.Background(), amount, to)
+}
+
+func (self *Account) BuildExactSendETHDynamicFeeTxContext(ctx context.Context, amount *big.Int, to string) (*types.Transaction, error) {
+	to, err := self.reader.ResolveAddressContext(ctx, to)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve address: %s", err)
+	}
+	chainID, nonce, tipCap, feeCap, err := self.dynamicFeeTxParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ethutils.BuildExactDynamicFeeTxWei(chainID, nonce, to, amount, 30000, tipCap, feeCap, []byte{}), nil
+}
+
+// ExportUnsignedTx writes the unsigned tx to file in the unsigned tx file
+// format (see ethutils.UnsignedTxFile) so it can be signed offline by
+// this account. Legacy, access list and dynamic fee txs are supported.
+func (self *Account) ExportUnsignedTx(tx *types.Transaction, file string, description string) error {
+	return self.ExportUnsignedTxContext(context.Background(), tx, file, description)
+}
+
+func (self *Account) ExportUnsignedTxContext(ctx context.Context, tx *types.Transaction, file string, description string) error {
+	chainID, err := self.reader.ChainIDContext(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get chain id: %s", err)
+	}
+	return ethutils.WriteUnsignedTxFile(
+		file,
+		ethutils.NewUnsignedTxFile(chainID, self.Address(), tx, description),
+	)
+}
+
+// SignTxFile reads an unsigned tx file, signs the tx with signer and
+// writes the result to signedFile in the signed tx file format. It
+// doesn't need any node so it can run on an offline machine. It fails if
+// the signer is not the account the unsigned tx file expects.
+func SignTxFile(signer Signer, unsignedFile string, signedFile string) (*types.Transaction, error) {
+	unsigned, err := ethutils.ReadUnsignedTxFile(unsignedFile)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := signer.SignTx(unsigned.Tx.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't sign the tx: %s", err)
+	}
+	signed, err := ethutils.NewSignedTxFile(unsigned, signedTx)
+	if err != nil {
+		return nil, err
+	}
+	return signedTx, ethutils.WriteSignedTxFile(signedFile, signed)
+}
+
+// SignTxFile signs an unsigned tx file with the account's signer, see
+// the SignTxFile function.
+func (self *Account) SignTxFile(unsignedFile string, signedFile string) (*types.Transaction, error) {
+	return SignTxFile(self.signer, unsignedFile, signedFile)
+}
@@ -19,6 +19,9 @@ type TrezorSigner struct {
 }
 
 func (self *TrezorSigner) SignTx(tx *types.Transaction) (*types.Transaction, error) {
+	if tx.Type() != types.LegacyTxType {
+		return tx, fmt.Errorf("trezor only supports signing legacy txs, got tx type %d", tx.Type())
+	}
 	self.mu.Lock()
 	defer self.mu.Unlock()
 	fmt.Printf("Going to proceed signing procedure\n")
@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
@@ -29,12 +30,11 @@ func AddressFromPrivateKey(key *ecdsa.PrivateKey) string {
 	return crypto.PubkeyToAddress(key.PublicKey).Hex()
 }
 
+// AddressFromHex returns the address of a hex private key, with or
+// without 0x prefix
 func AddressFromHex(hex string) (string, error) {
-	key, err := crypto.HexToECDSA(hex[2:])
-	if err != nil {
-		return "", err
-	}
-	return AddressFromPrivateKey(key), nil
+	pubhex, _, err := PrivateKeyFromHex(hex)
+	return pubhex, err
 }
 
 func PrivateKeyFromKeystore(file string, password string) (string, *ecdsa.PrivateKey, error) {
@@ -52,10 +52,7 @@ func PrivateKeyFromKeystore(file string, password string) (string, *ecdsa.Privat
 
 // works with both 0x prefix form and naked form
 func PrivateKeyFromHex(hex string) (string, *ecdsa.PrivateKey, error) {
-	if hex[0:2] == "0x" {
-		hex = hex[2:]
-	}
-	privkey, err := crypto.HexToECDSA(hex)
+	privkey, err := crypto.HexToECDSA(strings.TrimPrefix(hex, "0x"))
 	if err != nil {
 		return "", nil, err
 	} else {
@@ -14,12 +14,25 @@ import (
 	"github.com/tranvictor/ethutils"
 )
 
+const TIMEOUT time.Duration = 4 * time.Second
+
 // Broadcaster takes a signed tx and try to broadcast it to all
 // nodes that it manages as fast as possible. It returns a map of
 // failures and a bool indicating that the tx is broadcasted to
 // at least 1 node
 type Broadcaster struct {
 	clients map[string]*rpc.Client
+
+	// see SetRetryPolicy
+	retryPolicy ethutils.RetryPolicy
+}
+
+// SetRetryPolicy makes sending the tx to a node be retried according to
+// policy when it fails with a transient error, see
+// ethutils.IsRetryableError. A retry answered with "already known" and
+// the like counts as sent since an earlier attempt got to the node. A nil policy disables retries.
+func (self *Broadcaster) SetRetryPolicy(policy ethutils.RetryPolicy) {
+	self.retryPolicy = policy
 }
 
 func (self *Broadcaster) GetNodes() map[string]*rpc.Client {
@@ -31,7 +44,19 @@ func (self *Broadcaster) broadcast(
 	id string, client *rpc.Client, data string,
 	wg *sync.WaitGroup, failures *sync.Map) {
 	defer wg.Done()
-	err := client.CallContext(ctx, nil, "eth_sendRawTransaction", data)
+	attempt := 0
+	err := ethutils.Retry(ctx, self.retryPolicy, func() error {
+		attempt += 1
+		timeout, cancel := withTimeout(ctx, TIMEOUT)
+		defer cancel()
+		err := client.CallContext(timeout, nil, "eth_sendRawTransaction", data)
+		if err != nil && attempt > 1 && isKnownTxError(err) {
+			// an earlier attempt reached the node even though it timed
+			// out on our side
+			return nil
+		}
+		return err
+	})
 
 	if err != nil {
 		failures.Store(id, err)
@@ -39,25 +64,38 @@ func (self *Broadcaster) broadcast(
 }
 
 func (self *Broadcaster) BroadcastTx(tx *types.Transaction) (string, bool, error) {
+	return self.BroadcastTxContext(context.Background(), tx)
+}
+
+func (self *Broadcaster) BroadcastTxContext(ctx context.Context, tx *types.Transaction) (string, bool, error) {
 	data, err := rlp.EncodeToBytes(tx)
 	if err != nil {
 		return "", false, makeError(map[string]error{
 			"tx": fmt.Errorf("Tx is not valid, couldn't use rlp to encode it"),
 		})
 	}
-	return self.Broadcast(hexutil.Encode(data))
+	return self.BroadcastContext(ctx, hexutil.Encode(data))
 }
 
 // data must be hex encoded of the signed tx
 func (self *Broadcaster) Broadcast(data string) (string, bool, error) {
+	return self.BroadcastContext(context.Background(), data)
+}
+
+// BroadcastContext works as Broadcast and stops sending when ctx is done.
+// Without a deadline in ctx, each attempt to send to a node times out
+// after TIMEOUT.
+func (self *Broadcaster) BroadcastContext(ctx context.Context, data string) (string, bool, error) {
+	hash, err := ethutils.ParseRawTxHash(data)
+	if err != nil {
+		return "", false, makeError(map[string]error{"tx": err})
+	}
 	failures := sync.Map{}
 	wg := sync.WaitGroup{}
 	for id, _ := range self.clients {
 		wg.Add(1)
-		timeout, cancel := context.WithTimeout(context.Background(), 4*time.Second)
 		cli := self.clients[id]
-		go self.broadcast(timeout, id, cli, data, &wg, &failures)
-		defer cancel()
+		go self.broadcast(ctx, id, cli, data, &wg, &failures)
 	}
 	wg.Wait()
 	result := map[string]error{}
@@ -75,7 +113,24 @@ func (self *Broadcaster) Broadcast(data string) (string, bool, error) {
 		result[k] = err
 		return true
 	})
-	return ethutils.RawTxToHash(data), len(result) != len(self.clients) && len(self.clients) > 0, makeError(result)
+	return hash, len(result) != len(self.clients) && len(self.clients) > 0, makeError(result)
+}
+
+// BroadcastSignedTxFile reads a signed tx file written by an offline
+// signer (see ethutils.SignedTxFile), validates it and broadcasts the
+// signed tx.
+func (self *Broadcaster) BroadcastSignedTxFile(file string) (string, bool, error) {
+	return self.BroadcastSignedTxFileContext(context.Background(), file)
+}
+
+func (self *Broadcaster) BroadcastSignedTxFileContext(ctx context.Context, file string) (string, bool, error) {
+	signed, err := ethutils.ReadSignedTxFile(file)
+	if err != nil {
+		return "", false, makeError(map[string]error{
+			"tx": fmt.Errorf("Signed tx file is not valid: %s", err),
+		})
+	}
+	return self.BroadcastContext(ctx, signed.RawTxHex())
 }
 
 func NewGenericBroadcaster(nodes map[string]string) *Broadcaster {
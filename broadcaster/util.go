@@ -1,9 +1,32 @@
 package broadcaster
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 )
 
+// messages nodes reply with when they already have the tx, which is what
+// a node that got an earlier attempt says. "nonce too low" is not one of
+// them as it also means another tx with the same nonce was mined.
+var knownTxMessages = []string{
+	"already known",
+	"known transaction",
+	"already imported",
+	"already in pool",
+}
+
+func isKnownTxError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, m := range knownTxMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
 func makeError(errors map[string]error) error {
 	if len(errors) == 0 {
 		return nil
@@ -15,3 +38,12 @@ func makeError(errors map[string]error) error {
 		return fmt.Errorf("%s", errStr)
 	}
 }
+
+// withTimeout bounds ctx by timeout unless the caller already gave it a
+// deadline
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,27 @@
+package broadcaster
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIsKnownTxError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{fmt.Errorf("already known"), true},
+		{fmt.Errorf("known transaction: 0x1234"), true},
+		{fmt.Errorf("Transaction with the same hash was already imported."), true},
+		{fmt.Errorf("tx already in pool"), true},
+		// a tx with the same nonce may have been mined instead
+		{fmt.Errorf("nonce too low"), false},
+		{fmt.Errorf("replacement transaction underpriced"), false},
+		{fmt.Errorf("insufficient funds for gas * price + value"), false},
+	}
+	for _, c := range cases {
+		if got := isKnownTxError(c.err); got != c.want {
+			t.Errorf("isKnownTxError(%q) = %t, want %t", c.err, got, c.want)
+		}
+	}
+}
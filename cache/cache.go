@@ -0,0 +1,74 @@
+// Package cache provides key value caches for data that never changes
+// once it is final on chain, such as receipts and historical headers.
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache stores values by key. Implementations must be safe for
+// concurrent use. A cache can drop any value at any time.
+type Cache interface {
+	Get(key string) (value []byte, found bool)
+	Set(key string, value []byte)
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+// LRUCache is an in-memory cache keeping the most recently used values
+// up to a number of entries
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  *list.List
+	index    map[string]*list.Element
+}
+
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRUCache{
+		mu:       sync.Mutex{},
+		capacity: capacity,
+		entries:  list.New(),
+		index:    map[string]*list.Element{},
+	}
+}
+
+func (self *LRUCache) Get(key string) ([]byte, bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	e, found := self.index[key]
+	if !found {
+		return nil, false
+	}
+	self.entries.MoveToFront(e)
+	return e.Value.(*lruEntry).value, true
+}
+
+func (self *LRUCache) Set(key string, value []byte) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	if e, found := self.index[key]; found {
+		e.Value.(*lruEntry).value = value
+		self.entries.MoveToFront(e)
+		return
+	}
+	self.index[key] = self.entries.PushFront(&lruEntry{key, value})
+	for self.entries.Len() > self.capacity {
+		oldest := self.entries.Back()
+		self.entries.Remove(oldest)
+		delete(self.index, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (self *LRUCache) Len() int {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.entries.Len()
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+)
+
+type cacheOp struct {
+	set   bool
+	key   string
+	value string
+}
+
+func TestLRUCache(t *testing.T) {
+	set := func(key, value string) cacheOp { return cacheOp{true, key, value} }
+	get := func(key string) cacheOp { return cacheOp{false, key, ""} }
+	cases := []struct {
+		name     string
+		capacity int
+		ops      []cacheOp
+		want     map[string]string
+	}{
+		{
+			"evicts the oldest",
+			2,
+			[]cacheOp{set("a", "1"), set("b", "2"), set("c", "3")},
+			map[string]string{"b": "2", "c": "3"},
+		},
+		{
+			"get refreshes",
+			2,
+			[]cacheOp{set("a", "1"), set("b", "2"), get("a"), set("c", "3")},
+			map[string]string{"a": "1", "c": "3"},
+		},
+		{
+			"update refreshes without growing",
+			2,
+			[]cacheOp{set("a", "1"), set("b", "2"), set("a", "10"), set("c", "3")},
+			map[string]string{"a": "10", "c": "3"},
+		},
+		{
+			"capacity is at least 1",
+			0,
+			[]cacheOp{set("a", "1"), set("b", "2")},
+			map[string]string{"b": "2"},
+		},
+	}
+	for _, c := range cases {
+		cache := NewLRUCache(c.capacity)
+		for _, op := range c.ops {
+			if op.set {
+				cache.Set(op.key, []byte(op.value))
+			} else {
+				cache.Get(op.key)
+			}
+		}
+		if cache.Len() != len(c.want) {
+			t.Errorf("%s: Len = %d, want %d", c.name, cache.Len(), len(c.want))
+		}
+		for _, key := range []string{"a", "b", "c"} {
+			value, found := cache.Get(key)
+			want, wantFound := c.want[key]
+			if found != wantFound || string(value) != want {
+				t.Errorf("%s: Get(%s) = %q, %t, want %q, %t", c.name, key, value, found, want, wantFound)
+			}
+		}
+	}
+}
+
+func TestDiskCacheSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		cache.Set(fmt.Sprintf("1/receipt/%d", i), []byte(fmt.Sprintf("value %d", i)))
+	}
+	cache.Set("1/receipt/3", []byte("updated"))
+
+	reopened, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		want := fmt.Sprintf("value %d", i)
+		if i == 3 {
+			want = "updated"
+		}
+		value, found := reopened.Get(fmt.Sprintf("1/receipt/%d", i))
+		if !found || string(value) != want {
+			t.Errorf("Get(%d) = %q, %t, want %q", i, value, found, want)
+		}
+	}
+	if value, found := reopened.Get("1/receipt/10"); found {
+		t.Errorf("missing key found with %q", value)
+	}
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// DiskCache stores every value in its own file under a directory so it
+// survives restarts and can be shared by several processes. It never
+// evicts anything.
+type DiskCache struct {
+	dir string
+}
+
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// path spreads the files over 256 sub directories named by the first
+// byte of the hash of the key
+func (self *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(self.dir, name[:2], name)
+}
+
+func (self *DiskCache) Get(key string) ([]byte, bool) {
+	value, err := ioutil.ReadFile(self.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set writes to a temporary file first and renames it so readers never
+// see a partially written value
+func (self *DiskCache) Set(key string, value []byte) {
+	path := self.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Printf("DiskCache: couldn't create %s: %s", filepath.Dir(path), err)
+		return
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		log.Printf("DiskCache: couldn't create a temporary file: %s", err)
+		return
+	}
+	_, err = tmp.Write(value)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		log.Printf("DiskCache: couldn't write %s: %s", path, err)
+	}
+}
@@ -0,0 +1,53 @@
+package ethutils
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DETERMINISTIC_DEPLOYMENT_PROXY is the CREATE2 factory deployed with a
+// keyless tx at the same address on most EVM chains. It takes
+// salt (32 bytes) ‖ init code as call data and deploys the init code
+// with CREATE2.
+const DETERMINISTIC_DEPLOYMENT_PROXY string = "0x4e59b44847b379578588920cA78FbF26c0B4956C"
+
+// ContractInitCode returns the bytecode of a contract followed by its
+// abi encoded constructor params, which is the init code run by CREATE
+// and CREATE2
+func ContractInitCode(abiJson string, bytecode []byte, params ...interface{}) ([]byte, error) {
+	a, err := abi.JSON(strings.NewReader(abiJson))
+	if err != nil {
+		return nil, err
+	}
+	input, err := a.Pack("", params...)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]byte, 0, len(bytecode)+len(input))
+	result = append(result, bytecode...)
+	return append(result, input...), nil
+}
+
+// Create2Address returns the address of the contract deployed by
+// deployer with CREATE2 from salt and initCode:
+// keccak256(0xff ‖ deployer ‖ salt ‖ keccak256(initCode))[12:]
+func Create2Address(deployer string, salt common.Hash, initCode []byte) common.Address {
+	return Create2AddressFromInitCodeHash(deployer, salt, crypto.Keccak256Hash(initCode))
+}
+
+// Create2AddressFromInitCodeHash works as Create2Address when only the
+// hash of the init code is known
+func Create2AddressFromInitCodeHash(deployer string, salt common.Hash, initCodeHash common.Hash) common.Address {
+	return crypto.CreateAddress2(HexToAddress(deployer), salt, initCodeHash.Bytes())
+}
+
+// PackDeterministicDeploymentData returns the call data making
+// DETERMINISTIC_DEPLOYMENT_PROXY deploy initCode with salt
+func PackDeterministicDeploymentData(salt common.Hash, initCode []byte) []byte {
+	result := make([]byte, 0, common.HashLength+len(initCode))
+	result = append(result, salt.Bytes()...)
+	return append(result, initCode...)
+}
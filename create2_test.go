@@ -0,0 +1,55 @@
+package ethutils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestCreate2Address(t *testing.T) {
+	// the examples of EIP-1014
+	cases := []struct {
+		deployer string
+		salt     string
+		initCode string
+		want     string
+	}{
+		{"0x0000000000000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000", "0x00", "0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38"},
+		{"0xdeadbeef00000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000", "0x00", "0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3"},
+		{"0xdeadbeef00000000000000000000000000000000", "0x000000000000000000000000feed000000000000000000000000000000000000", "0x00", "0xD04116cDd17beBE565EB2422F2497E06cC1C9833"},
+		{"0x0000000000000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000", "0xdeadbeef", "0x70f2b2914A2a4b783FaEFb75f459A580616Fcb5e"},
+		{"0x00000000000000000000000000000000deadbeef", "0x00000000000000000000000000000000000000000000000000000000cafebabe", "0xdeadbeef", "0x60f3f640a8508fC6a86d45DF051962668E1e8AC7"},
+		{"0x00000000000000000000000000000000deadbeef", "0x00000000000000000000000000000000000000000000000000000000cafebabe", "0x" + strings.Repeat("deadbeef", 11), "0x1d8bfDC5D46DC4f61D6b6115972536eBE6A8854C"},
+		{"0x0000000000000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000", "0x", "0xE33C0C7F7df4809055C3ebA6c09CFe4BaF1BD9e0"},
+	}
+	for i, c := range cases {
+		salt := common.HexToHash(c.salt)
+		initCode := common.FromHex(c.initCode)
+		got := Create2Address(c.deployer, salt, initCode)
+		if got.Hex() != c.want {
+			t.Errorf("case %d: Create2Address = %s, want %s", i, got.Hex(), c.want)
+		}
+		got = Create2AddressFromInitCodeHash(c.deployer, salt, crypto.Keccak256Hash(initCode))
+		if got.Hex() != c.want {
+			t.Errorf("case %d: Create2AddressFromInitCodeHash = %s, want %s", i, got.Hex(), c.want)
+		}
+	}
+}
+
+func TestPackDeterministicDeploymentData(t *testing.T) {
+	salt := common.HexToHash("0x00000000000000000000000000000000000000000000000000000000cafebabe")
+	initCode := common.FromHex("0xdeadbeef")
+	data := PackDeterministicDeploymentData(salt, initCode)
+	want := common.FromHex("0x00000000000000000000000000000000000000000000000000000000cafebabedeadbeef")
+	if !bytes.Equal(data, want) {
+		t.Fatalf("PackDeterministicDeploymentData = 0x%s, want 0x%s", common.Bytes2Hex(data), common.Bytes2Hex(want))
+	}
+	// the proxy deploys the data after the salt with CREATE2
+	got := Create2Address(DETERMINISTIC_DEPLOYMENT_PROXY, common.BytesToHash(data[:32]), data[32:])
+	if want := crypto.CreateAddress2(common.HexToAddress(DETERMINISTIC_DEPLOYMENT_PROXY), salt, crypto.Keccak256(initCode)); got != want {
+		t.Errorf("deployed address = %s, want %s", got.Hex(), want.Hex())
+	}
+}
@@ -0,0 +1,459 @@
+// Package eip712 encodes and hashes EIP-712 typed structured data as used
+// by eth_signTypedData_v4, permits (EIP-2612), Gnosis Safe txs and
+// off-chain orders.
+package eip712
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const DOMAIN_TYPE string = "EIP712Domain"
+
+// Field is one member of a struct type
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types maps struct type names to their members
+type Types map[string][]Field
+
+// TypedData is the JSON object signed by eth_signTypedData_v4. Domain and
+// Message values can be either JSON decoded values (strings, numbers,
+// bools, maps and slices) or Go values such as *big.Int, common.Address
+// and []byte.
+type TypedData struct {
+	Types       Types                  `json:"types"`
+	PrimaryType string                 `json:"primaryType"`
+	Domain      map[string]interface{} `json:"domain"`
+	Message     map[string]interface{} `json:"message"`
+}
+
+// the EIP712Domain members in the order defined by the EIP, used when
+// the typed data doesn't declare EIP712Domain itself
+var domainFields = []Field{
+	{"name", "string"},
+	{"version", "string"},
+	{"chainId", "uint256"},
+	{"verifyingContract", "address"},
+	{"salt", "bytes32"},
+}
+
+var arraySuffix = regexp.MustCompile(`\[(\d*)\]$`)
+
+var identifier = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z0-9_$]*$`)
+
+// ParseTypedData decodes the JSON form of typed data and validates it.
+// Numbers are kept as json.Number so big integers don't lose precision.
+func ParseTypedData(data []byte) (*TypedData, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	result := &TypedData{}
+	if err := decoder.Decode(result); err != nil {
+		return nil, fmt.Errorf("couldn't parse typed data: %w", err)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// splitArray returns the element type and the length of an array type.
+// length is -1 for dynamic arrays. isArray is false for non array types.
+func splitArray(typ string) (elem string, length int, isArray bool) {
+	m := arraySuffix.FindStringSubmatchIndex(typ)
+	if m == nil {
+		return typ, 0, false
+	}
+	if m[2] == m[3] {
+		return typ[:m[0]], -1, true
+	}
+	length, _ = strconv.Atoi(typ[m[2]:m[3]])
+	return typ[:m[0]], length, true
+}
+
+// baseType strips every array suffix of a type
+func baseType(typ string) string {
+	for {
+		elem, _, isArray := splitArray(typ)
+		if !isArray {
+			return typ
+		}
+		typ = elem
+	}
+}
+
+// types returns the declared types with EIP712Domain derived from the
+// domain values if it is not declared
+func (self *TypedData) types() Types {
+	if _, found := self.Types[DOMAIN_TYPE]; found {
+		return self.Types
+	}
+	result := Types{}
+	for name, fields := range self.Types {
+		result[name] = fields
+	}
+	fields := []Field{}
+	for _, f := range domainFields {
+		if _, found := self.Domain[f.Name]; found {
+			fields = append(fields, f)
+		}
+	}
+	result[DOMAIN_TYPE] = fields
+	return result
+}
+
+func validateAtomicType(typ string) error {
+	t, err := abi.NewType(typ, "", nil)
+	if err != nil {
+		return err
+	}
+	switch t.T {
+	case abi.IntTy, abi.UintTy, abi.BoolTy, abi.AddressTy, abi.FixedBytesTy, abi.BytesTy, abi.StringTy:
+		return nil
+	default:
+		return fmt.Errorf("type %s is not supported by EIP-712", typ)
+	}
+}
+
+// Validate checks that the primary type is declared, that every member
+// refers to a declared struct or a valid solidity type and that member
+// names are unique
+func (self *TypedData) Validate() error {
+	types := self.types()
+	if _, found := types[self.PrimaryType]; !found {
+		return fmt.Errorf("primary type %s is not declared", self.PrimaryType)
+	}
+	for name, fields := range types {
+		if !identifier.MatchString(name) {
+			return fmt.Errorf("invalid type name: %s", name)
+		}
+		if _, err := abi.NewType(name, "", nil); err == nil {
+			return fmt.Errorf("type name %s collides with a solidity type", name)
+		}
+		seen := map[string]bool{}
+		for _, f := range fields {
+			if f.Name == "" {
+				return fmt.Errorf("%s has a member without name", name)
+			}
+			if seen[f.Name] {
+				return fmt.Errorf("%s has duplicated member %s", name, f.Name)
+			}
+			seen[f.Name] = true
+			base := baseType(f.Type)
+			if _, found := types[base]; found {
+				continue
+			}
+			if err := validateAtomicType(base); err != nil {
+				return fmt.Errorf("%s.%s: %w", name, f.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// dependencies collects the struct types referenced by typ, typ included
+func (self *TypedData) dependencies(types Types, typ string, found map[string]bool) {
+	typ = baseType(typ)
+	if found[typ] {
+		return
+	}
+	fields, isStruct := types[typ]
+	if !isStruct {
+		return
+	}
+	found[typ] = true
+	for _, f := range fields {
+		self.dependencies(types, f.Type, found)
+	}
+}
+
+// EncodeType returns the encodeType of a struct type, eg.
+// Mail(Person from,Person to,string contents)Person(string name,address wallet)
+func (self *TypedData) EncodeType(typ string) (string, error) {
+	types := self.types()
+	if _, found := types[typ]; !found {
+		return "", fmt.Errorf("type %s is not declared", typ)
+	}
+	found := map[string]bool{}
+	self.dependencies(types, typ, found)
+	deps := []string{}
+	for dep := range found {
+		if dep != typ {
+			deps = append(deps, dep)
+		}
+	}
+	sort.Strings(deps)
+	result := ""
+	for _, dep := range append([]string{typ}, deps...) {
+		members := []string{}
+		for _, f := range types[dep] {
+			members = append(members, f.Type+" "+f.Name)
+		}
+		result += fmt.Sprintf("%s(%s)", dep, strings.Join(members, ","))
+	}
+	return result, nil
+}
+
+// TypeHash returns keccak256 of the encodeType of a struct type
+func (self *TypedData) TypeHash(typ string) (common.Hash, error) {
+	encoded, err := self.EncodeType(typ)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash([]byte(encoded)), nil
+}
+
+// EncodeData returns the encodeData of a struct value, that is its type
+// hash followed by the 32 bytes encoding of each member
+func (self *TypedData) EncodeData(typ string, data map[string]interface{}) ([]byte, error) {
+	types := self.types()
+	fields, found := types[typ]
+	if !found {
+		return nil, fmt.Errorf("type %s is not declared", typ)
+	}
+	for name := range data {
+		declared := false
+		for _, f := range fields {
+			if f.Name == name {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			return nil, fmt.Errorf("%s has no member %s", typ, name)
+		}
+	}
+	typeHash, err := self.TypeHash(typ)
+	if err != nil {
+		return nil, err
+	}
+	result := typeHash.Bytes()
+	for _, f := range fields {
+		value, found := data[f.Name]
+		if !found {
+			return nil, fmt.Errorf("%s.%s is missing", typ, f.Name)
+		}
+		encoded, err := self.encodeValue(types, f.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typ, f.Name, err)
+		}
+		result = append(result, encoded...)
+	}
+	return result, nil
+}
+
+// HashStruct returns keccak256 of the encodeData of a struct value
+func (self *TypedData) HashStruct(typ string, data map[string]interface{}) (common.Hash, error) {
+	encoded, err := self.EncodeData(typ, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// DomainSeparator returns the hashStruct of the domain
+func (self *TypedData) DomainSeparator() (common.Hash, error) {
+	return self.HashStruct(DOMAIN_TYPE, self.Domain)
+}
+
+// Hash returns the digest to be signed:
+// keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+func (self *TypedData) Hash() (common.Hash, error) {
+	if err := self.Validate(); err != nil {
+		return common.Hash{}, err
+	}
+	domainSeparator, err := self.DomainSeparator()
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encoding domain failed: %w", err)
+	}
+	messageHash, err := self.HashStruct(self.PrimaryType, self.Message)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encoding message failed: %w", err)
+	}
+	return crypto.Keccak256Hash(
+		[]byte{0x19, 0x01},
+		domainSeparator.Bytes(),
+		messageHash.Bytes(),
+	), nil
+}
+
+func (self *TypedData) encodeValue(types Types, typ string, value interface{}) ([]byte, error) {
+	if elem, length, isArray := splitArray(typ); isArray {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an array for %s, got %T", typ, value)
+		}
+		if length >= 0 && len(items) != length {
+			return nil, fmt.Errorf("expected %d items for %s, got %d", length, typ, len(items))
+		}
+		encoded := []byte{}
+		for i, item := range items {
+			e, err := self.encodeValue(types, elem, item)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+			encoded = append(encoded, e...)
+		}
+		return crypto.Keccak256(encoded), nil
+	}
+	if _, isStruct := types[typ]; isStruct {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object for %s, got %T", typ, value)
+		}
+		hash, err := self.HashStruct(typ, data)
+		if err != nil {
+			return nil, err
+		}
+		return hash.Bytes(), nil
+	}
+	return encodeAtomic(typ, value)
+}
+
+func encodeAtomic(typ string, value interface{}) ([]byte, error) {
+	t, err := abi.NewType(typ, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	switch t.T {
+	case abi.StringTy:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, got %T", value)
+		}
+		return crypto.Keccak256([]byte(s)), nil
+	case abi.BytesTy:
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(b), nil
+	case abi.FixedBytesTy:
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > t.Size {
+			return nil, fmt.Errorf("%d bytes don't fit in %s", len(b), typ)
+		}
+		return common.RightPadBytes(b, 32), nil
+	case abi.AddressTy:
+		switch v := value.(type) {
+		case common.Address:
+			return common.LeftPadBytes(v.Bytes(), 32), nil
+		case string:
+			if !common.IsHexAddress(v) {
+				return nil, fmt.Errorf("invalid address: %s", v)
+			}
+			return common.LeftPadBytes(common.HexToAddress(v).Bytes(), 32), nil
+		default:
+			return nil, fmt.Errorf("expected an address, got %T", value)
+		}
+	case abi.BoolTy:
+		switch v := value.(type) {
+		case bool:
+			if v {
+				return math.U256Bytes(big.NewInt(1)), nil
+			}
+			return math.U256Bytes(big.NewInt(0)), nil
+		default:
+			return nil, fmt.Errorf("expected a bool, got %T", value)
+		}
+	case abi.IntTy, abi.UintTy:
+		n, err := toBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkIntRange(t, n); err != nil {
+			return nil, err
+		}
+		return math.U256Bytes(new(big.Int).Set(n)), nil
+	default:
+		return nil, fmt.Errorf("type %s is not supported by EIP-712", typ)
+	}
+}
+
+func checkIntRange(t abi.Type, n *big.Int) error {
+	if t.T == abi.UintTy {
+		if n.Sign() < 0 || n.BitLen() > t.Size {
+			return fmt.Errorf("%s doesn't fit in uint%d", n, t.Size)
+		}
+		return nil
+	}
+	max := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+	min := new(big.Int).Neg(max)
+	if n.Cmp(min) < 0 || n.Cmp(max) >= 0 {
+		return fmt.Errorf("%s doesn't fit in int%d", n, t.Size)
+	}
+	return nil
+}
+
+func toBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case common.Hash:
+		return v.Bytes(), nil
+	case string:
+		b, err := hexutil.Decode(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex bytes %s: %w", v, err)
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("expected bytes, got %T", value)
+	}
+}
+
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		return v, nil
+	case json.Number:
+		return parseInteger(string(v))
+	case string:
+		return parseInteger(v)
+	case float64:
+		n, accuracy := big.NewFloat(v).Int(nil)
+		if accuracy != big.Exact {
+			return nil, fmt.Errorf("%v is not an integer", v)
+		}
+		return n, nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	default:
+		return nil, fmt.Errorf("expected an integer, got %T", value)
+	}
+}
+
+// parseInteger accepts decimal and 0x prefixed hex integers
+func parseInteger(s string) (*big.Int, error) {
+	n, ok := math.ParseBig256(s)
+	if ok {
+		return n, nil
+	}
+	if strings.HasPrefix(s, "-") {
+		if n, ok := math.ParseBig256(s[1:]); ok {
+			return n.Neg(n), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid integer: %s", s)
+}
@@ -0,0 +1,256 @@
+package eip712
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// the example of the EIP-712 specification
+const mailJSON = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func parse(t *testing.T, data string) *TypedData {
+	t.Helper()
+	result, err := ParseTypedData([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseTypedData failed: %s", err)
+	}
+	return result
+}
+
+func TestMailExample(t *testing.T) {
+	td := parse(t, mailJSON)
+	encoded, err := td.EncodeType("Mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Mail(Person from,Person to,string contents)Person(string name,address wallet)"; encoded != want {
+		t.Errorf("EncodeType = %q, want %q", encoded, want)
+	}
+	checks := []struct {
+		name string
+		get  func() (common.Hash, error)
+		want string
+	}{
+		{"type hash", func() (common.Hash, error) { return td.TypeHash("Mail") }, "0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2"},
+		{"message hash", func() (common.Hash, error) { return td.HashStruct("Mail", td.Message) }, "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"},
+		{"domain separator", td.DomainSeparator, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"},
+		{"digest", td.Hash, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"},
+	}
+	for _, c := range checks {
+		got, err := c.get()
+		if err != nil {
+			t.Errorf("%s failed: %s", c.name, err)
+			continue
+		}
+		if got.Hex() != c.want {
+			t.Errorf("%s = %s, want %s", c.name, got.Hex(), c.want)
+		}
+	}
+}
+
+func TestMailExampleDerivedDomain(t *testing.T) {
+	// EIP712Domain is derived from the domain values when it is not declared
+	td := parse(t, mailJSON)
+	delete(td.Types, DOMAIN_TYPE)
+	got, err := td.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Hex() != "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2" {
+		t.Errorf("digest = %s", got.Hex())
+	}
+}
+
+func word(b []byte) []byte {
+	return common.LeftPadBytes(b, 32)
+}
+
+func keccak(parts ...[]byte) []byte {
+	return crypto.Keccak256(parts...)
+}
+
+func TestNestedStructs(t *testing.T) {
+	td := parse(t, `{
+		"types": {
+			"Order": [
+				{"name": "asset", "type": "Asset"},
+				{"name": "maker", "type": "Person"}
+			],
+			"Asset": [
+				{"name": "token", "type": "Token"},
+				{"name": "amount", "type": "uint256"}
+			],
+			"Token": [{"name": "addr", "type": "address"}],
+			"Person": [
+				{"name": "name", "type": "string"},
+				{"name": "wallet", "type": "address"}
+			]
+		},
+		"primaryType": "Order",
+		"domain": {"name": "Test", "chainId": 5},
+		"message": {
+			"asset": {"token": {"addr": "0x1111111111111111111111111111111111111111"}, "amount": "1000000000000000000"},
+			"maker": {"name": "Alice", "wallet": "0x2222222222222222222222222222222222222222"}
+		}
+	}`)
+	// referenced types are sorted by name after the primary type
+	encoded, err := td.EncodeType("Order")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Order(Asset asset,Person maker)Asset(Token token,uint256 amount)Person(string name,address wallet)Token(address addr)"
+	if encoded != want {
+		t.Fatalf("EncodeType = %q, want %q", encoded, want)
+	}
+
+	tokenHash := keccak(
+		keccak([]byte("Token(address addr)")),
+		word(common.HexToAddress("0x1111111111111111111111111111111111111111").Bytes()),
+	)
+	assetHash := keccak(
+		keccak([]byte("Asset(Token token,uint256 amount)Token(address addr)")),
+		tokenHash,
+		math.U256Bytes(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)),
+	)
+	personHash := keccak(
+		keccak([]byte("Person(string name,address wallet)")),
+		keccak([]byte("Alice")),
+		word(common.HexToAddress("0x2222222222222222222222222222222222222222").Bytes()),
+	)
+	orderHash := keccak(keccak([]byte(want)), assetHash, personHash)
+	got, err := td.HashStruct("Order", td.Message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Bytes(), orderHash) {
+		t.Errorf("HashStruct = %s, want %s", got.Hex(), common.BytesToHash(orderHash).Hex())
+	}
+
+	domainHash := keccak(
+		keccak([]byte("EIP712Domain(string name,uint256 chainId)")),
+		keccak([]byte("Test")),
+		word(big.NewInt(5).Bytes()),
+	)
+	digest, err := td.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := keccak([]byte{0x19, 0x01}, domainHash, orderHash); !bytes.Equal(digest.Bytes(), want) {
+		t.Errorf("Hash = %s, want %s", digest.Hex(), common.BytesToHash(want).Hex())
+	}
+}
+
+func TestArrays(t *testing.T) {
+	td := parse(t, `{
+		"types": {
+			"Group": [
+				{"name": "name", "type": "string"},
+				{"name": "members", "type": "Person[]"},
+				{"name": "ids", "type": "uint8[2]"},
+				{"name": "tags", "type": "bytes32[]"}
+			],
+			"Person": [
+				{"name": "name", "type": "string"},
+				{"name": "wallets", "type": "address[]"}
+			]
+		},
+		"primaryType": "Group",
+		"domain": {"name": "Test"},
+		"message": {
+			"name": "Team",
+			"members": [
+				{"name": "Bob", "wallets": ["0x3333333333333333333333333333333333333333", "0x4444444444444444444444444444444444444444"]},
+				{"name": "Eve", "wallets": []}
+			],
+			"ids": [1, 2],
+			"tags": []
+		}
+	}`)
+	encoded, err := td.EncodeType("Group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Group(string name,Person[] members,uint8[2] ids,bytes32[] tags)Person(string name,address[] wallets)"
+	if encoded != want {
+		t.Fatalf("EncodeType = %q, want %q", encoded, want)
+	}
+
+	personType := keccak([]byte("Person(string name,address[] wallets)"))
+	bob := keccak(
+		personType,
+		keccak([]byte("Bob")),
+		keccak(
+			word(common.HexToAddress("0x3333333333333333333333333333333333333333").Bytes()),
+			word(common.HexToAddress("0x4444444444444444444444444444444444444444").Bytes()),
+		),
+	)
+	eve := keccak(personType, keccak([]byte("Eve")), keccak())
+	groupHash := keccak(
+		keccak([]byte(want)),
+		keccak([]byte("Team")),
+		keccak(bob, eve),
+		keccak(word([]byte{1}), word([]byte{2})),
+		keccak(),
+	)
+	got, err := td.HashStruct("Group", td.Message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Bytes(), groupHash) {
+		t.Errorf("HashStruct = %s, want %s", got.Hex(), common.BytesToHash(groupHash).Hex())
+	}
+
+	td.Message["ids"] = []interface{}{1}
+	if _, err := td.Hash(); err == nil {
+		t.Errorf("a fixed size array with the wrong length was accepted")
+	}
+}
+
+func TestInvalidTypedData(t *testing.T) {
+	cases := []string{
+		// unknown type
+		`{"types": {"Mail": [{"name": "from", "type": "Human"}]}, "primaryType": "Mail", "domain": {}, "message": {}}`,
+		// undeclared primary type
+		`{"types": {"Mail": [{"name": "contents", "type": "string"}]}, "primaryType": "Letter", "domain": {}, "message": {}}`,
+	}
+	for i, c := range cases {
+		if _, err := ParseTypedData([]byte(c)); err == nil {
+			t.Errorf("case %d was accepted", i)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package ethutils
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ENS_REGISTRY_ADDRESS is the ENS registry deployed at the same address
+// on mainnet and the ethereum testnets
+const ENS_REGISTRY_ADDRESS string = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+
+// ENS_REVERSE_SUFFIX is the domain under which reverse records live
+const ENS_REVERSE_SUFFIX string = "addr.reverse"
+
+// LowercaseENSName lower cases the name and trims surrounding spaces.
+// This is not the UTS-46 normalization ENS specifies, names with non
+// ascii characters must be normalized by the caller.
+func LowercaseENSName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// IsENSName returns true if s looks like an ENS name such as vitalik.eth
+// rather than a hex address
+func IsENSName(s string) bool {
+	s = LowercaseENSName(s)
+	if common.IsHexAddress(s) || !strings.Contains(s, ".") {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// NameHash returns the ENS namehash of the name lower cased with
+// LowercaseENSName
+func NameHash(name string) common.Hash {
+	node := common.Hash{}
+	name = LowercaseENSName(name)
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := crypto.Keccak256([]byte(labels[i]))
+		node = crypto.Keccak256Hash(node.Bytes(), labelHash)
+	}
+	return node
+}
+
+// ReverseENSName returns the name of the reverse record of an address,
+// eg. d8da6bf26964af9d7eed9e03e53415d37aa96045.addr.reverse
+func ReverseENSName(address common.Address) string {
+	return strings.ToLower(address.Hex()[2:]) + "." + ENS_REVERSE_SUFFIX
+}
+
+func GetENSRegistryABI() *abi.ABI {
+	result, _ := abi.JSON(strings.NewReader(ensregistryabi))
+	return &result
+}
+
+func GetENSResolverABI() *abi.ABI {
+	result, _ := abi.JSON(strings.NewReader(ensresolverabi))
+	return &result
+}
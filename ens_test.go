@@ -0,0 +1,45 @@
+package ethutils
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNameHash(t *testing.T) {
+	// vectors from EIP-137
+	cases := []struct {
+		name string
+		hash string
+	}{
+		{"", "0x0000000000000000000000000000000000000000000000000000000000000000"},
+		{"eth", "0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae"},
+		{"foo.eth", "0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f"},
+		{" Foo.ETH ", "0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f"},
+	}
+	for _, c := range cases {
+		if got := NameHash(c.name); got != common.HexToHash(c.hash) {
+			t.Errorf("NameHash(%q) = %s, want %s", c.name, got.Hex(), c.hash)
+		}
+	}
+}
+
+func TestIsENSName(t *testing.T) {
+	cases := []struct {
+		s    string
+		want bool
+	}{
+		{"vitalik.eth", true},
+		{"sub.Vitalik.ETH", true},
+		{"eth", false},
+		{"vitalik..eth", false},
+		{".eth", false},
+		{"0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045", false},
+		{"", false},
+	}
+	for _, c := range cases {
+		if got := IsENSName(c.s); got != c.want {
+			t.Errorf("IsENSName(%q) = %v, want %v", c.s, got, c.want)
+		}
+	}
+}
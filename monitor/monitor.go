@@ -1,6 +1,7 @@
 package monitor
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -28,56 +29,99 @@ func NewTxMonitor() *TxMonitor {
 	}
 }
 
-func (self TxMonitor) periodicCheck(tx string, info chan eu.TxInfo) {
+// periodicCheck sends the final state of tx to info. It closes info
+// without sending anything if ctx is done first.
+func (self TxMonitor) periodicCheck(ctx context.Context, tx string, info chan eu.TxInfo) {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 	startTime := time.Now()
 	isOnNode := false
+	send := func(txinfo eu.TxInfo) {
+		select {
+		case info <- txinfo:
+		case <-ctx.Done():
+			close(info)
+		}
+	}
 	for {
-		t := <-ticker.C
-		txinfo, _ := self.reader.TxInfoFromHash(tx)
+		var t time.Time
+		select {
+		case <-ctx.Done():
+			close(info)
+			return
+		case t = <-ticker.C:
+		}
+		txinfo, _ := self.reader.TxInfoFromHashContext(ctx, tx)
 		st, tx, receipt := txinfo.Status, txinfo.Tx, txinfo.Receipt
 		switch st {
-		case "error":
+		case eu.TxStatusError:
 			continue
-		case "notfound":
+		case eu.TxStatusNotFound:
 			if t.Sub(startTime) > 3*time.Minute && !isOnNode {
-				info <- eu.TxInfo{"lost", tx, []eu.InternalTx{}, receipt, nil}
+				send(eu.TxInfo{
+					Status:      eu.TxStatusLost,
+					Tx:          tx,
+					InternalTxs: []eu.InternalTx{},
+					Receipt:     receipt,
+				})
 				return
 			} else {
 				continue
 			}
-		case "pending":
+		case eu.TxStatusPending:
 			isOnNode = true
 			continue
-		case "reverted":
-			block, _ := self.reader.HeaderByNumber(receipt.BlockNumber.Int64())
-			info <- eu.TxInfo{"reverted", tx, []eu.InternalTx{}, receipt, block}
-			return
-		case "done":
-			block, _ := self.reader.HeaderByNumber(receipt.BlockNumber.Int64())
-			info <- eu.TxInfo{"done", tx, []eu.InternalTx{}, receipt, block}
+		case eu.TxStatusReverted, eu.TxStatusDone:
+			block, _ := self.reader.HeaderByNumberContext(ctx, receipt.BlockNumber.Int64())
+			send(eu.TxInfo{
+				Status:      st,
+				Tx:          tx,
+				InternalTxs: []eu.InternalTx{},
+				Receipt:     receipt,
+				BlockHeader: block,
+			})
 			return
 		}
 	}
 }
 
 func (self TxMonitor) MakeWaitChannel(tx string) <-chan eu.TxInfo {
+	return self.MakeWaitChannelContext(context.Background(), tx)
+}
+
+// MakeWaitChannelContext works as MakeWaitChannel and stops monitoring
+// when ctx is done, in which case the channel is closed without a value
+func (self TxMonitor) MakeWaitChannelContext(ctx context.Context, tx string) <-chan eu.TxInfo {
 	result := make(chan eu.TxInfo)
-	go self.periodicCheck(tx, result)
+	go self.periodicCheck(ctx, tx, result)
 	return result
 }
 
 func (self TxMonitor) BlockingWait(tx string) eu.TxInfo {
-	wChannel := self.MakeWaitChannel(tx)
-	return <-wChannel
+	info, _ := self.BlockingWaitContext(context.Background(), tx)
+	return info
+}
+
+// BlockingWaitContext works as BlockingWait and returns ctx.Err() if ctx
+// is done before tx is mined or lost
+func (self TxMonitor) BlockingWaitContext(ctx context.Context, tx string) (eu.TxInfo, error) {
+	wChannel := self.MakeWaitChannelContext(ctx, tx)
+	info, ok := <-wChannel
+	if !ok {
+		return eu.TxInfo{}, ctx.Err()
+	}
+	return info, nil
 }
 
 func (self TxMonitor) MakeWaitChannelForMultipleTxs(txs ...string) []<-chan eu.TxInfo {
+	return self.MakeWaitChannelForMultipleTxsContext(context.Background(), txs...)
+}
+
+func (self TxMonitor) MakeWaitChannelForMultipleTxsContext(ctx context.Context, txs ...string) []<-chan eu.TxInfo {
 	result := [](<-chan eu.TxInfo){}
 	for _, tx := range txs {
 		ch := make(chan eu.TxInfo)
-		go self.periodicCheck(tx, ch)
+		go self.periodicCheck(ctx, tx, ch)
 		result = append(result, ch)
 	}
 	return result
@@ -85,14 +129,24 @@ func (self TxMonitor) MakeWaitChannelForMultipleTxs(txs ...string) []<-chan eu.T
 
 func waitForChannel(wg *sync.WaitGroup, channel <-chan eu.TxInfo, result *sync.Map) {
 	defer wg.Done()
-	info := <-channel
+	info, ok := <-channel
+	if !ok {
+		return
+	}
 	result.Store(info.Tx.Hash().Hex(), info)
 }
 
 func (self TxMonitor) BlockingWaitForMultipleTxs(txs ...string) map[string]eu.TxInfo {
+	result, _ := self.BlockingWaitForMultipleTxsContext(context.Background(), txs...)
+	return result
+}
+
+// BlockingWaitForMultipleTxsContext works as BlockingWaitForMultipleTxs.
+// If ctx is done first, it returns the txs finished so far and ctx.Err().
+func (self TxMonitor) BlockingWaitForMultipleTxsContext(ctx context.Context, txs ...string) (map[string]eu.TxInfo, error) {
 	resultMap := sync.Map{}
 	wg := sync.WaitGroup{}
-	channels := self.MakeWaitChannelForMultipleTxs(txs...)
+	channels := self.MakeWaitChannelForMultipleTxsContext(ctx, txs...)
 	for _, channel := range channels {
 		wg.Add(1)
 		go waitForChannel(&wg, channel, &resultMap)
@@ -103,5 +157,8 @@ func (self TxMonitor) BlockingWaitForMultipleTxs(txs ...string) map[string]eu.Tx
 		result[key.(string)] = value.(eu.TxInfo)
 		return true
 	})
-	return result
+	if len(result) < len(txs) {
+		return result, ctx.Err()
+	}
+	return result, nil
 }
@@ -0,0 +1,204 @@
+package ethutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Air-gapped signing works with 2 JSON files:
+//
+// 1. The unsigned tx file is written on the online machine, which knows
+// the nonce and the gas price, and is carried to the offline machine:
+//
+//	{
+//	  "version": 1,
+//	  "chainId": 1,
+//	  "from": "0x...",          // the account expected to sign the tx
+//	  "description": "...",     // optional note for the person signing
+//	  "tx": { ... }             // the unsigned tx in Transaction JSON form
+//	}
+//
+// 2. The signed tx file is written by the offline machine and carried
+// back to the online machine to be broadcasted:
+//
+//	{
+//	  "version": 1,
+//	  "chainId": 1,
+//	  "from": "0x...",
+//	  "description": "...",
+//	  "hash": "0x...",          // hash of the signed tx
+//	  "rawTx": "0x...",         // hex of the signed tx, as sent to eth_sendRawTransaction
+//	  "tx": { ... }             // the signed tx in Transaction JSON form, for review
+//	}
+//
+// "tx" is encoded with Transaction.MarshalJSON so every tx type is
+// supported.
+
+const OFFLINE_TX_FILE_VERSION int = 1
+
+type UnsignedTxFile struct {
+	Version     int            `json:"version"`
+	ChainID     int64          `json:"chainId"`
+	From        common.Address `json:"from"`
+	Description string         `json:"description,omitempty"`
+	Tx          *Transaction   `json:"tx"`
+}
+
+type SignedTxFile struct {
+	Version     int            `json:"version"`
+	ChainID     int64          `json:"chainId"`
+	From        common.Address `json:"from"`
+	Description string         `json:"description,omitempty"`
+	Hash        common.Hash    `json:"hash"`
+	RawTx       hexutil.Bytes  `json:"rawTx"`
+	Tx          *Transaction   `json:"tx"`
+}
+
+func NewUnsignedTxFile(chainID int64, from string, tx *types.Transaction, description string) *UnsignedTxFile {
+	return &UnsignedTxFile{
+		Version:     OFFLINE_TX_FILE_VERSION,
+		ChainID:     chainID,
+		From:        common.HexToAddress(from),
+		Description: description,
+		Tx:          &Transaction{Transaction: tx},
+	}
+}
+
+// Validate checks that the file is of a supported version and the tx is
+// not signed yet
+func (self *UnsignedTxFile) Validate() error {
+	if self.Version != OFFLINE_TX_FILE_VERSION {
+		return fmt.Errorf("unsupported unsigned tx file version: %d", self.Version)
+	}
+	if self.Tx == nil || self.Tx.Transaction == nil {
+		return fmt.Errorf("unsigned tx file has no tx")
+	}
+	if v, r, s := self.Tx.RawSignatureValues(); v.Sign() != 0 || r.Sign() != 0 || s.Sign() != 0 {
+		return fmt.Errorf("tx in the unsigned tx file is already signed")
+	}
+	if self.Tx.Type() != types.LegacyTxType && self.Tx.ChainId().Int64() != self.ChainID {
+		return fmt.Errorf(
+			"tx chain id %d doesn't match the file chain id %d",
+			self.Tx.ChainId().Int64(), self.ChainID,
+		)
+	}
+	return nil
+}
+
+// NewSignedTxFile creates the signed tx file that answers an unsigned tx
+// file. It fails if the signed tx is not the tx of the unsigned file or
+// is not signed by the expected account.
+func NewSignedTxFile(unsigned *UnsignedTxFile, signedTx *types.Transaction) (*SignedTxFile, error) {
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encode the signed tx: %w", err)
+	}
+	result := &SignedTxFile{
+		Version:     OFFLINE_TX_FILE_VERSION,
+		ChainID:     unsigned.ChainID,
+		From:        unsigned.From,
+		Description: unsigned.Description,
+		Hash:        signedTx.Hash(),
+		RawTx:       raw,
+	}
+	if err := result.Validate(); err != nil {
+		return nil, err
+	}
+	// the signing hash covers every field of the tx except the signature
+	var signer types.Signer = types.HomesteadSigner{}
+	if signedTx.Protected() {
+		signer = types.LatestSignerForChainID(big.NewInt(unsigned.ChainID))
+	}
+	if signer.Hash(unsigned.Tx.Transaction) != signer.Hash(signedTx) {
+		return nil, fmt.Errorf("signed tx is not the tx of the unsigned tx file")
+	}
+	return result, nil
+}
+
+// Validate decodes the raw tx and checks that it matches the hash, the
+// sender and the chain id recorded in the file. It fills Tx with the
+// decoded tx.
+func (self *SignedTxFile) Validate() error {
+	if self.Version != OFFLINE_TX_FILE_VERSION {
+		return fmt.Errorf("unsupported signed tx file version: %d", self.Version)
+	}
+	tx, err := DecodeRawTx(hexutil.Encode(self.RawTx))
+	if err != nil {
+		return err
+	}
+	if tx.Hash() != self.Hash {
+		return fmt.Errorf("raw tx hash %s doesn't match the file hash %s", tx.Hash().Hex(), self.Hash.Hex())
+	}
+	if *tx.Extra.From != self.From {
+		return fmt.Errorf("raw tx is signed by %s, expected %s", tx.Extra.From.Hex(), self.From.Hex())
+	}
+	if tx.Protected() && tx.ChainId().Int64() != self.ChainID {
+		return fmt.Errorf("raw tx chain id %d doesn't match the file chain id %d", tx.ChainId().Int64(), self.ChainID)
+	}
+	self.Tx = tx
+	return nil
+}
+
+// RawTxHex returns the signed tx in the hex form accepted by
+// Broadcaster.Broadcast
+func (self *SignedTxFile) RawTxHex() string {
+	return hexutil.Encode(self.RawTx)
+}
+
+func writeJSONFile(file string, content interface{}) error {
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0600)
+}
+
+func WriteUnsignedTxFile(file string, content *UnsignedTxFile) error {
+	if err := content.Validate(); err != nil {
+		return err
+	}
+	return writeJSONFile(file, content)
+}
+
+func ReadUnsignedTxFile(file string) (*UnsignedTxFile, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	result := &UnsignedTxFile{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("couldn't parse unsigned tx file: %w", err)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func WriteSignedTxFile(file string, content *SignedTxFile) error {
+	if err := content.Validate(); err != nil {
+		return err
+	}
+	return writeJSONFile(file, content)
+}
+
+func ReadSignedTxFile(file string) (*SignedTxFile, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	result := &SignedTxFile{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("couldn't parse signed tx file: %w", err)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
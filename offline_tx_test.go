@@ -0,0 +1,111 @@
+package ethutils
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestOfflineTxFilesRoundTrip(t *testing.T) {
+	key, err := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := int64(5)
+	to := "0x00000000000000000000000000000000000A11cE"
+	value := big.NewInt(1234567890123456789)
+	data := []byte{0xa9, 0x05, 0x9c, 0xbb}
+	accessList := types.AccessList{{
+		Address:     common.HexToAddress(to),
+		StorageKeys: []common.Hash{common.HexToHash("0x01")},
+	}}
+	cases := []struct {
+		name string
+		tx   *types.Transaction
+	}{
+		{"legacy", BuildExactTx(7, to, value, 21000, 1.5, data)},
+		{"access list", BuildExactAccessListTx(chainID, 7, to, value, 30000, 1.5, data, accessList)},
+		{"dynamic fee", BuildExactDynamicFeeTx(chainID, 7, to, value, 21000, 1, 100.5, data)},
+	}
+	for _, c := range cases {
+		dir := t.TempDir()
+		unsignedPath := filepath.Join(dir, "unsigned.json")
+		signedPath := filepath.Join(dir, "signed.json")
+
+		err := WriteUnsignedTxFile(unsignedPath, NewUnsignedTxFile(chainID, from.Hex(), c.tx, c.name))
+		if err != nil {
+			t.Fatalf("%s: WriteUnsignedTxFile failed: %s", c.name, err)
+		}
+		unsigned, err := ReadUnsignedTxFile(unsignedPath)
+		if err != nil {
+			t.Fatalf("%s: ReadUnsignedTxFile failed: %s", c.name, err)
+		}
+		if unsigned.ChainID != chainID || unsigned.From != from || unsigned.Description != c.name {
+			t.Fatalf("%s: unsigned file header changed: %+v", c.name, unsigned)
+		}
+		signer := types.NewLondonSigner(big.NewInt(chainID))
+		if signer.Hash(unsigned.Tx.Transaction) != signer.Hash(c.tx) {
+			t.Fatalf("%s: unsigned tx changed after the round trip", c.name)
+		}
+
+		signedTx, err := types.SignTx(unsigned.Tx.Transaction, signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signedFile, err := NewSignedTxFile(unsigned, signedTx)
+		if err != nil {
+			t.Fatalf("%s: NewSignedTxFile failed: %s", c.name, err)
+		}
+		if err := WriteSignedTxFile(signedPath, signedFile); err != nil {
+			t.Fatalf("%s: WriteSignedTxFile failed: %s", c.name, err)
+		}
+		signed, err := ReadSignedTxFile(signedPath)
+		if err != nil {
+			t.Fatalf("%s: ReadSignedTxFile failed: %s", c.name, err)
+		}
+		if err := signed.Validate(); err != nil {
+			t.Fatalf("%s: Validate failed: %s", c.name, err)
+		}
+		if signed.Hash != signedTx.Hash() || *signed.Tx.Extra.From != from {
+			t.Fatalf("%s: signed file has hash %s from %s", c.name, signed.Hash.Hex(), signed.Tx.Extra.From.Hex())
+		}
+		raw, err := DecodeRawTx(signed.RawTxHex())
+		if err != nil {
+			t.Fatalf("%s: DecodeRawTx(RawTxHex()) failed: %s", c.name, err)
+		}
+		if raw.Hash() != signedTx.Hash() || raw.Type() != c.tx.Type() {
+			t.Fatalf("%s: raw tx is %s of type %d", c.name, raw.Hash().Hex(), raw.Type())
+		}
+	}
+}
+
+func TestNewSignedTxFileRejectsOtherTxs(t *testing.T) {
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	other, _ := crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+	chainID := int64(5)
+	signer := types.NewLondonSigner(big.NewInt(chainID))
+	to := "0x00000000000000000000000000000000000A11cE"
+	tx := BuildExactDynamicFeeTx(chainID, 7, to, big.NewInt(1), 21000, 1, 100, nil)
+	unsigned := NewUnsignedTxFile(chainID, crypto.PubkeyToAddress(key.PublicKey).Hex(), tx, "")
+
+	signedByOther, _ := types.SignTx(tx, signer, other)
+	if _, err := NewSignedTxFile(unsigned, signedByOther); err == nil {
+		t.Errorf("tx signed by another account was accepted")
+	}
+	otherTx, _ := types.SignTx(BuildExactDynamicFeeTx(chainID, 8, to, big.NewInt(1), 21000, 1, 100, nil), signer, key)
+	if _, err := NewSignedTxFile(unsigned, otherTx); err == nil {
+		t.Errorf("another tx signed by the account was accepted")
+	}
+	signedTx, _ := types.SignTx(tx, signer, key)
+	if err := NewUnsignedTxFile(chainID, crypto.PubkeyToAddress(key.PublicKey).Hex(), signedTx, "").Validate(); err == nil {
+		t.Errorf("unsigned tx file with a signed tx was accepted")
+	}
+	if err := NewUnsignedTxFile(1, crypto.PubkeyToAddress(key.PublicKey).Hex(), tx, "").Validate(); err == nil {
+		t.Errorf("unsigned tx file with another chain id was accepted")
+	}
+}
@@ -0,0 +1,144 @@
+package ethutils
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The Parse functions in this file are the strict, error returning
+// counterparts of HexToAddress, HexToHash, HexToBig, FloatToInt,
+// FloatToBigInt and RawTxToHash. They are meant for user supplied input.
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseAddress parses a 0x prefixed 20 bytes hex address. Mixed case
+// addresses must have a valid EIP-55 checksum, all lower case or all
+// upper case addresses are accepted without checksum.
+func ParseAddress(s string) (common.Address, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return common.Address{}, fmt.Errorf("invalid address %q: missing 0x prefix", s)
+	}
+	digits := s[2:]
+	if len(digits) != 2*common.AddressLength {
+		return common.Address{}, fmt.Errorf("invalid address %q: expected %d hex digits, got %d", s, 2*common.AddressLength, len(digits))
+	}
+	if !isHex(digits) {
+		return common.Address{}, fmt.Errorf("invalid address %q: not a hex string", s)
+	}
+	result := common.HexToAddress(s)
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) {
+		if result.Hex()[2:] != digits {
+			return common.Address{}, fmt.Errorf("invalid address %q: bad EIP-55 checksum, expected %s", s, result.Hex())
+		}
+	}
+	return result, nil
+}
+
+// ParseAddresses parses every address with ParseAddress
+func ParseAddresses(hexes []string) ([]common.Address, error) {
+	result := []common.Address{}
+	for _, h := range hexes {
+		addr, err := ParseAddress(h)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, addr)
+	}
+	return result, nil
+}
+
+// ParseHash parses a 0x prefixed 32 bytes hex hash
+func ParseHash(s string) (common.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid hash %q: %s", s, err)
+	}
+	if len(b) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid hash %q: expected %d bytes, got %d", s, common.HashLength, len(b))
+	}
+	return common.BytesToHash(b), nil
+}
+
+// ParseHexBig parses a 0x prefixed hex quantity such as 0x1a
+func ParseHexBig(s string) (*big.Int, error) {
+	result, err := hexutil.DecodeBig(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex number %q: %s", s, err)
+	}
+	return result, nil
+}
+
+// ParseBig parses a decimal integer or a 0x prefixed hex quantity
+func ParseBig(s string) (*big.Int, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return ParseHexBig(s)
+	}
+	result, ok := big.NewInt(0).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	return result, nil
+}
+
+// ParseFloatInt rounds amount to the nearest int64 as FloatToInt does.
+// It returns an error for NaN, infinite and out of range amounts.
+func ParseFloatInt(amount float64) (int64, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, fmt.Errorf("invalid amount: %v", amount)
+	}
+	result, err := strconv.ParseInt(fmt.Sprintf("%.0f", amount), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount %v doesn't fit in an int64", amount)
+	}
+	return result, nil
+}
+
+// ParseFloatAmount converts a non-negative float amount to a big int
+// with specific decimal the same way as FloatToBigInt. It returns an
+// error for negative, NaN and infinite amounts.
+func ParseFloatAmount(amount float64, decimal int64) (*big.Int, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return nil, fmt.Errorf("invalid amount: %v", amount)
+	}
+	if amount < 0 {
+		return nil, fmt.Errorf("amount must be non-negative, got %v", amount)
+	}
+	if decimal < 0 {
+		return nil, fmt.Errorf("decimal must be non-negative, got %d", decimal)
+	}
+	return FloatToBigInt(amount, decimal), nil
+}
+
+// roundFloat rounds a finite float to the nearest integer as a big int
+// without going through int64 so it never overflows
+func roundFloat(amount float64) *big.Int {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return big.NewInt(0)
+	}
+	result, _ := big.NewInt(0).SetString(strconv.FormatFloat(amount, 'f', 0, 64), 10)
+	return result
+}
+
+// ParseRawTxHash returns the hash of a hex encoded signed tx as
+// RawTxToHash does. It returns an error if data is not valid hex.
+func ParseRawTxHash(data string) (string, error) {
+	raw, err := hexutil.Decode(data)
+	if err != nil {
+		return "", fmt.Errorf("invalid raw tx hex: %s", err)
+	}
+	return crypto.Keccak256Hash(raw).Hex(), nil
+}
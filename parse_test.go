@@ -0,0 +1,145 @@
+package ethutils
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseAddress(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+		fails bool
+	}{
+		// EIP-55 test vectors
+		{input: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", want: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{input: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", want: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"},
+		{input: "0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB", want: "0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB"},
+		{input: "0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb", want: "0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb"},
+		// single case addresses carry no checksum
+		{input: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", want: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{input: "0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", want: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{input: "0X5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", want: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		// bad checksums
+		{input: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", fails: true},
+		{input: "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", fails: true},
+		{input: "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", fails: true},
+		{input: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea", fails: true},
+		{input: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed00", fails: true},
+		{input: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaeg", fails: true},
+		{input: "", fails: true},
+	}
+	for _, c := range cases {
+		got, err := ParseAddress(c.input)
+		if c.fails {
+			if err == nil {
+				t.Errorf("ParseAddress(%q) = %s, want an error", c.input, got.Hex())
+			}
+			continue
+		}
+		if err != nil || got.Hex() != c.want {
+			t.Errorf("ParseAddress(%q) = %s, %v, want %s", c.input, got.Hex(), err, c.want)
+		}
+	}
+}
+
+func TestParseHash(t *testing.T) {
+	hash := "0xb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf6"
+	cases := []struct {
+		input string
+		fails bool
+	}{
+		{input: hash},
+		{input: "0xB10E2D527612073B26EECDFD717E6A320CF44B4AFAC2B0732D9FCBE2B7FA0CF6"},
+		{input: hash[2:], fails: true},
+		{input: hash[:64], fails: true},
+		{input: hash + "00", fails: true},
+		{input: hash[:65] + "z", fails: true},
+		{input: "0x", fails: true},
+	}
+	for _, c := range cases {
+		got, err := ParseHash(c.input)
+		if c.fails {
+			if err == nil {
+				t.Errorf("ParseHash(%q) = %s, want an error", c.input, got.Hex())
+			}
+			continue
+		}
+		if err != nil || got != common.HexToHash(hash) {
+			t.Errorf("ParseHash(%q) = %s, %v, want %s", c.input, got.Hex(), err, hash)
+		}
+	}
+}
+
+func TestParseHexBig(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+		fails bool
+	}{
+		{input: "0x0", want: "0"},
+		{input: "0x1a", want: "26"},
+		{input: "0xDE0B6B3A7640000", want: "1000000000000000000"},
+		{input: "0x" + "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", want: maxUint256},
+		{input: "1a", fails: true},
+		{input: "0x", fails: true},
+		{input: "0x01", fails: true},
+		{input: "0xg", fails: true},
+		{input: "26", fails: true},
+	}
+	for _, c := range cases {
+		got, err := ParseHexBig(c.input)
+		if c.fails {
+			if err == nil {
+				t.Errorf("ParseHexBig(%q) = %s, want an error", c.input, got)
+			}
+			continue
+		}
+		if err != nil || got.String() != c.want {
+			t.Errorf("ParseHexBig(%q) = %v, %v, want %s", c.input, got, err, c.want)
+		}
+	}
+}
+
+func TestParseFloatAmount(t *testing.T) {
+	cases := []struct {
+		amount  float64
+		decimal int64
+		want    string
+		fails   bool
+	}{
+		{amount: 1, decimal: 18, want: "1000000000000000000"},
+		{amount: 1.234, decimal: 4, want: "12340"},
+		{amount: 0.1, decimal: 18, want: "100000000000000000"},
+		{amount: 0, decimal: 18, want: "0"},
+		{amount: 1e30, decimal: 0, want: "1000000000000000019884624838656"},
+		{amount: math.NaN(), decimal: 18, fails: true},
+		{amount: math.Inf(1), decimal: 18, fails: true},
+		{amount: math.Inf(-1), decimal: 18, fails: true},
+		{amount: -1, decimal: 18, fails: true},
+		{amount: 1, decimal: -1, fails: true},
+	}
+	for _, c := range cases {
+		got, err := ParseFloatAmount(c.amount, c.decimal)
+		if c.fails {
+			if err == nil {
+				t.Errorf("ParseFloatAmount(%v, %d) = %s, want an error", c.amount, c.decimal, got)
+			}
+			continue
+		}
+		if err != nil || got.String() != c.want {
+			t.Errorf("ParseFloatAmount(%v, %d) = %v, %v, want %s", c.amount, c.decimal, got, err, c.want)
+		}
+	}
+}
+
+func TestFloatToBigIntInvalidAmounts(t *testing.T) {
+	// documented to return 0, ParseFloatAmount rejects them
+	for _, amount := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if got := FloatToBigInt(amount, 18); got.Sign() != 0 {
+			t.Errorf("FloatToBigInt(%v, 18) = %s, want 0", amount, got)
+		}
+	}
+}
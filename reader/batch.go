@@ -0,0 +1,243 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	eu "github.com/tranvictor/ethutils"
+)
+
+// SetMaxBatchSize bounds the number of requests every node sends in one
+// JSON-RPC batch, see OneNodeReader.SetMaxBatchSize
+func (self *EthReader) SetMaxBatchSize(size int) {
+	for _, n := range self.nodes {
+		if setter, ok := n.(interface{ SetMaxBatchSize(int) }); ok {
+			setter.SetMaxBatchSize(size)
+		}
+	}
+}
+
+type batchResponse struct {
+	Results []interface{}
+	Errors  []error
+	Error   error
+}
+
+// batchRead sends a batch of size elements to every node. The results
+// come from the first node answering and the elements it failed are
+// taken from the other nodes as they answer. It returns an error only
+// when no node answered the batch.
+func (self *EthReader) batchRead(ctx context.Context, size int, read func(n ContextEthereumNode) ([]interface{}, []error, error)) ([]interface{}, []error, error) {
+	if size == 0 {
+		return []interface{}{}, []error{}, nil
+	}
+	nodes := self.activeNodes()
+	resCh := make(chan batchResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
+		go func() {
+			var results []interface{}
+			var elemErrs []error
+			err := self.callNode(ctx, n, func() (err error) {
+				results, elemErrs, err = read(n)
+				if err == nil && (len(results) != size || len(elemErrs) != size) {
+					err = fmt.Errorf("batch returned %d results and %d errors for %d requests", len(results), len(elemErrs), size)
+				}
+				return err
+			})
+			resCh <- batchResponse{
+				Results: results,
+				Errors:  elemErrs,
+				Error:   wrapError(err, n.NodeName()),
+			}
+		}()
+	}
+	var results []interface{}
+	var elemErrs []error
+	var filled []bool
+	missing := size
+	errs := []error{}
+	for i := 0; i < len(nodes); i++ {
+		res := <-resCh
+		if res.Error != nil {
+			errs = append(errs, res.Error)
+			continue
+		}
+		if results == nil {
+			results = make([]interface{}, size)
+			elemErrs = make([]error, size)
+			filled = make([]bool, size)
+		}
+		for j := 0; j < size; j++ {
+			if filled[j] {
+				continue
+			}
+			if res.Errors[j] == nil {
+				results[j] = res.Results[j]
+				elemErrs[j] = nil
+				filled[j] = true
+				missing -= 1
+			} else if elemErrs[j] == nil {
+				elemErrs[j] = res.Errors[j]
+			}
+		}
+		if missing == 0 {
+			break
+		}
+	}
+	if results == nil {
+		return nil, nil, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
+	}
+	return results, elemErrs, nil
+}
+
+// resolveBatchAddresses resolves the ENS names of a batch. The elements
+// that can't be resolved get their error in errs and are left out of
+// resolved, indexes maps resolved back to the positions in the batch.
+func (self *EthReader) resolveBatchAddresses(ctx context.Context, block BlockRef, addresses []string) (resolved []string, indexes []int, errs []error) {
+	errs = make([]error, len(addresses))
+	for i, a := range addresses {
+		addr, err := self.ResolveAddressAtContext(ctx, block, a)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		resolved = append(resolved, addr)
+		indexes = append(indexes, i)
+	}
+	return resolved, indexes, errs
+}
+
+func (self *EthReader) BatchGetBalancesAt(addresses []string, block BlockRef) ([]*big.Int, []error, error) {
+	return self.BatchGetBalancesAtContext(context.Background(), addresses, block)
+}
+
+// BatchGetBalancesAtContext reads the balances of many addresses or ENS
+// names in JSON-RPC batches. It returns a balance and an error per
+// address, the last error is set when no node could serve the batch.
+func (self *EthReader) BatchGetBalancesAtContext(ctx context.Context, addresses []string, block BlockRef) ([]*big.Int, []error, error) {
+	resolved, indexes, errs := self.resolveBatchAddresses(ctx, block, addresses)
+	results, elemErrs, err := self.batchRead(ctx, len(resolved), func(n ContextEthereumNode) ([]interface{}, []error, error) {
+		balances, errs, err := n.BatchGetBalancesAtContext(ctx, resolved, block)
+		values := []interface{}{}
+		for _, b := range balances {
+			values = append(values, b)
+		}
+		return values, errs, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	balances := make([]*big.Int, len(addresses))
+	for i, index := range indexes {
+		balances[index], _ = results[i].(*big.Int)
+		errs[index] = elemErrs[i]
+	}
+	return balances, errs, nil
+}
+
+func (self *EthReader) BatchGetNoncesAt(addresses []string, block BlockRef) ([]uint64, []error, error) {
+	return self.BatchGetNoncesAtContext(context.Background(), addresses, block)
+}
+
+// BatchGetNoncesAtContext works as BatchGetBalancesAtContext for nonces
+func (self *EthReader) BatchGetNoncesAtContext(ctx context.Context, addresses []string, block BlockRef) ([]uint64, []error, error) {
+	resolved, indexes, errs := self.resolveBatchAddresses(ctx, block, addresses)
+	results, elemErrs, err := self.batchRead(ctx, len(resolved), func(n ContextEthereumNode) ([]interface{}, []error, error) {
+		nonces, errs, err := n.BatchGetNoncesAtContext(ctx, resolved, block)
+		values := []interface{}{}
+		for _, nonce := range nonces {
+			values = append(values, nonce)
+		}
+		return values, errs, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	nonces := make([]uint64, len(addresses))
+	for i, index := range indexes {
+		nonces[index], _ = results[i].(uint64)
+		errs[index] = elemErrs[i]
+	}
+	return nonces, errs, nil
+}
+
+func (self *EthReader) BatchTransactionReceipts(txHashes []string) ([]*types.Receipt, []error, error) {
+	return self.BatchTransactionReceiptsContext(context.Background(), txHashes)
+}
+
+// BatchTransactionReceiptsContext reads many receipts in JSON-RPC
+// batches. The error of a tx that is not mined is ethereum.NotFound.
+func (self *EthReader) BatchTransactionReceiptsContext(ctx context.Context, txHashes []string) ([]*types.Receipt, []error, error) {
+	results, errs, err := self.batchRead(ctx, len(txHashes), func(n ContextEthereumNode) ([]interface{}, []error, error) {
+		receipts, errs, err := n.BatchTransactionReceiptsContext(ctx, txHashes)
+		values := []interface{}{}
+		for _, r := range receipts {
+			values = append(values, r)
+		}
+		return values, errs, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	receipts := make([]*types.Receipt, len(txHashes))
+	for i, r := range results {
+		receipts[i], _ = r.(*types.Receipt)
+	}
+	return receipts, errs, nil
+}
+
+func (self *EthReader) BatchTransactionsByHash(txHashes []string) ([]*eu.Transaction, []error, error) {
+	return self.BatchTransactionsByHashContext(context.Background(), txHashes)
+}
+
+// BatchTransactionsByHashContext reads many txs in JSON-RPC batches.
+// Pending txs have a nil Extra.BlockNumber and the error of an unknown tx
+// is ethereum.NotFound.
+func (self *EthReader) BatchTransactionsByHashContext(ctx context.Context, txHashes []string) ([]*eu.Transaction, []error, error) {
+	results, errs, err := self.batchRead(ctx, len(txHashes), func(n ContextEthereumNode) ([]interface{}, []error, error) {
+		txs, errs, err := n.BatchTransactionsByHashContext(ctx, txHashes)
+		values := []interface{}{}
+		for _, tx := range txs {
+			values = append(values, tx)
+		}
+		return values, errs, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	txs := make([]*eu.Transaction, len(txHashes))
+	for i, tx := range results {
+		txs[i], _ = tx.(*eu.Transaction)
+	}
+	return txs, errs, nil
+}
+
+func (self *EthReader) BatchHeadersByNumber(numbers []int64) ([]*types.Header, []error, error) {
+	return self.BatchHeadersByNumberContext(context.Background(), numbers)
+}
+
+// BatchHeadersByNumberContext reads many headers in JSON-RPC batches. As
+// in HeaderByNumber, 0 is the genesis block and a negative number means
+// the latest block, unlike the atBlock parameters of the history methods
+// where 0 also means the latest block, see BlockRefFromInt64.
+func (self *EthReader) BatchHeadersByNumberContext(ctx context.Context, numbers []int64) ([]*types.Header, []error, error) {
+	results, errs, err := self.batchRead(ctx, len(numbers), func(n ContextEthereumNode) ([]interface{}, []error, error) {
+		headers, errs, err := n.BatchHeadersByNumberContext(ctx, numbers)
+		values := []interface{}{}
+		for _, h := range headers {
+			values = append(values, h)
+		}
+		return values, errs, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	headers := make([]*types.Header, len(numbers))
+	for i, h := range results {
+		headers[i], _ = h.(*types.Header)
+	}
+	return headers, errs, nil
+}
@@ -0,0 +1,175 @@
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestBatchReadMergesNodes(t *testing.T) {
+	down := fmt.Errorf("connection refused")
+	// answer is what a node returns for a batch of 3: the failed elements
+	// and the error of the whole batch
+	type answer struct {
+		failed []int
+		err    error
+		short  bool
+	}
+	cases := []struct {
+		name    string
+		answers map[string]answer
+		results []interface{}
+		failed  []int
+		err     bool
+	}{
+		{"one node", map[string]answer{"a": {}}, []interface{}{0, 1, 2}, []int{}, false},
+		{"elements from other nodes", map[string]answer{"a": {failed: []int{1}}, "b": {failed: []int{0, 2}}}, []interface{}{0, 1, 2}, []int{}, false},
+		{"element failed everywhere", map[string]answer{"a": {failed: []int{1, 2}}, "b": {failed: []int{2}}}, []interface{}{0, 1, nil}, []int{2}, false},
+		{"failed batch", map[string]answer{"a": {err: down}, "b": {failed: []int{0}}}, []interface{}{nil, 1, 2}, []int{0}, false},
+		{"short batch", map[string]answer{"a": {short: true}, "b": {}}, []interface{}{0, 1, 2}, []int{}, false},
+		{"all batches failed", map[string]answer{"a": {err: down}, "b": {short: true}}, nil, nil, true},
+	}
+	for _, c := range cases {
+		nodes := []ContextEthereumNode{}
+		for name := range c.answers {
+			nodes = append(nodes, newFakeNode(name, 100))
+		}
+		r := newFakeReader(nodes...)
+		results, errs, err := r.batchRead(context.Background(), 3, func(n ContextEthereumNode) ([]interface{}, []error, error) {
+			a := c.answers[n.NodeName()]
+			if a.err != nil {
+				return nil, nil, a.err
+			}
+			results := []interface{}{0, 1, 2}
+			errs := []error{nil, nil, nil}
+			for _, i := range a.failed {
+				results[i] = nil
+				errs[i] = rpcError(fmt.Sprintf("%s failed %d", n.NodeName(), i))
+			}
+			if a.short {
+				return results[:2], errs[:2], nil
+			}
+			return results, errs, nil
+		})
+		if c.err {
+			if err == nil {
+				t.Errorf("%s: got %v, want an error", c.name, results)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		failed := []int{}
+		for i, e := range errs {
+			if e != nil {
+				failed = append(failed, i)
+			}
+		}
+		if !reflect.DeepEqual(results, c.results) || !reflect.DeepEqual(failed, c.failed) {
+			t.Errorf("%s: got %v with %v failed, want %v with %v failed", c.name, results, failed, c.results, c.failed)
+		}
+	}
+}
+
+func TestBatchCallRPCSplitsBatches(t *testing.T) {
+	cases := []struct {
+		maxBatchSize int
+		addresses    int
+		batches      []int
+	}{
+		{3, 7, []int{3, 3, 1}},
+		{3, 6, []int{3, 3}},
+		{10, 7, []int{7}},
+		{0, 7, []int{7}},
+	}
+	for _, c := range cases {
+		mu := sync.Mutex{}
+		batches := []int{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var requests []struct {
+				ID     json.RawMessage `json:"id"`
+				Params []interface{}   `json:"params"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&requests); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			mu.Lock()
+			batches = append(batches, len(requests))
+			mu.Unlock()
+			responses := []map[string]interface{}{}
+			for _, r := range requests {
+				// the balance of an address is its last byte
+				addr := common.HexToAddress(r.Params[0].(string))
+				responses = append(responses, map[string]interface{}{
+					"jsonrpc": "2.0",
+					"id":      r.ID,
+					"result":  hexutil.EncodeBig(big.NewInt(int64(addr[19]))),
+				})
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(responses)
+		}))
+		n := NewOneNodeReader("node", server.URL)
+		n.SetMaxBatchSize(c.maxBatchSize)
+		addresses := []string{}
+		for i := 1; i <= c.addresses; i++ {
+			addresses = append(addresses, common.BigToAddress(big.NewInt(int64(i))).Hex())
+		}
+		balances, errs, err := n.BatchGetBalancesAt(addresses, LatestBlock())
+		server.Close()
+		if err != nil {
+			t.Errorf("%d by %d: %s", c.addresses, c.maxBatchSize, err)
+			continue
+		}
+		for i := range addresses {
+			if errs[i] != nil || balances[i].Int64() != int64(i+1) {
+				t.Errorf("%d by %d: balance %d is %v, %v", c.addresses, c.maxBatchSize, i, balances[i], errs[i])
+			}
+		}
+		if !reflect.DeepEqual(batches, c.batches) {
+			t.Errorf("%d by %d: sent batches of %v, want %v", c.addresses, c.maxBatchSize, batches, c.batches)
+		}
+	}
+}
+
+func TestBatchGetBalancesResolvesENSNames(t *testing.T) {
+	alice := common.HexToAddress("0x00000000000000000000000000000000000a11ce")
+	bob := common.HexToAddress("0x0000000000000000000000000000000000000b0b")
+	n := newFakeNode("node", 100)
+	n.serveENS(map[string]common.Address{"alice.eth": alice})
+	requested := []string{}
+	n.balance = func(address string, block BlockRef) (*big.Int, error) {
+		requested = append(requested, address)
+		if common.HexToAddress(address) == alice {
+			return big.NewInt(1), nil
+		}
+		return big.NewInt(2), nil
+	}
+	r := newFakeReader(n)
+	balances, errs, err := r.BatchGetBalancesAt([]string{"nobody.eth", "alice.eth", "not an address", bob.Hex()}, LatestBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantBalances := []*big.Int{nil, big.NewInt(1), nil, big.NewInt(2)}
+	if !reflect.DeepEqual(balances, wantBalances) {
+		t.Errorf("got balances %v, want %v", balances, wantBalances)
+	}
+	if errs[0] == nil || errs[1] != nil || errs[2] == nil || errs[3] != nil {
+		t.Errorf("got errors %v, want errors for the unresolved names only", errs)
+	}
+	if !reflect.DeepEqual(requested, []string{alice.Hex(), bob.Hex()}) {
+		t.Errorf("node was asked for %v, want the resolved addresses only", requested)
+	}
+}
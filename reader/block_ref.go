@@ -0,0 +1,148 @@
+package reader
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Block tags understood by the nodes. "safe" and "finalized" are only
+// served by post merge nodes.
+const (
+	LATEST_BLOCK_TAG    string = "latest"
+	PENDING_BLOCK_TAG   string = "pending"
+	SAFE_BLOCK_TAG      string = "safe"
+	FINALIZED_BLOCK_TAG string = "finalized"
+	EARLIEST_BLOCK_TAG  string = "earliest"
+)
+
+// BlockRef selects the block a read is done at. It is either a block tag,
+// a block number or a block hash (EIP-1898). The zero value is the
+// latest block.
+type BlockRef struct {
+	tag              string
+	number           *big.Int
+	hash             *common.Hash
+	requireCanonical bool
+}
+
+func LatestBlock() BlockRef    { return BlockRef{tag: LATEST_BLOCK_TAG} }
+func PendingBlock() BlockRef   { return BlockRef{tag: PENDING_BLOCK_TAG} }
+func SafeBlock() BlockRef      { return BlockRef{tag: SAFE_BLOCK_TAG} }
+func FinalizedBlock() BlockRef { return BlockRef{tag: FINALIZED_BLOCK_TAG} }
+func EarliestBlock() BlockRef  { return BlockRef{tag: EARLIEST_BLOCK_TAG} }
+
+// BlockNumber refers to the block of the canonical chain at number
+func BlockNumber(number uint64) BlockRef {
+	return BlockRef{number: new(big.Int).SetUint64(number)}
+}
+
+// BlockHash refers to the block with the given hash. If requireCanonical
+// is true, the nodes reject the read when the block is not part of the
+// canonical chain anymore.
+func BlockHash(hash common.Hash, requireCanonical bool) BlockRef {
+	return BlockRef{hash: &hash, requireCanonical: requireCanonical}
+}
+
+// BlockRefFromInt64 converts the atBlock parameter of the legacy history
+// methods where any value <= 0 means the latest block
+func BlockRefFromInt64(atBlock int64) BlockRef {
+	if atBlock <= 0 {
+		return LatestBlock()
+	}
+	return BlockNumber(uint64(atBlock))
+}
+
+// ParseBlockRef parses a block tag, a decimal or 0x prefixed block number
+// or a 32 bytes block hash
+func ParseBlockRef(s string) (BlockRef, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case LATEST_BLOCK_TAG, PENDING_BLOCK_TAG, SAFE_BLOCK_TAG, FINALIZED_BLOCK_TAG, EARLIEST_BLOCK_TAG:
+		return BlockRef{tag: strings.ToLower(s)}, nil
+	}
+	if strings.HasPrefix(s, "0x") && len(s) == 2+2*common.HashLength {
+		hash, err := hexutil.Decode(s)
+		if err != nil {
+			return BlockRef{}, fmt.Errorf("invalid block hash %q: %s", s, err)
+		}
+		return BlockHash(common.BytesToHash(hash), false), nil
+	}
+	var number *big.Int
+	var err error
+	if strings.HasPrefix(s, "0x") {
+		number, err = hexutil.DecodeBig(s)
+	} else {
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			err = fmt.Errorf("not a number")
+		}
+		number = n
+	}
+	if err != nil || number.Sign() < 0 || !number.IsUint64() {
+		return BlockRef{}, fmt.Errorf("invalid block %q", s)
+	}
+	return BlockNumber(number.Uint64()), nil
+}
+
+// Tag returns the block tag if the ref is a tag. The zero value is the
+// latest tag.
+func (self BlockRef) Tag() (string, bool) {
+	if self.number != nil || self.hash != nil {
+		return "", false
+	}
+	if self.tag == "" {
+		return LATEST_BLOCK_TAG, true
+	}
+	return self.tag, true
+}
+
+func (self BlockRef) Number() (*big.Int, bool) {
+	if self.number == nil {
+		return nil, false
+	}
+	return new(big.Int).Set(self.number), true
+}
+
+func (self BlockRef) Hash() (common.Hash, bool) {
+	if self.hash == nil {
+		return common.Hash{}, false
+	}
+	return *self.hash, true
+}
+
+func (self BlockRef) IsLatest() bool {
+	tag, isTag := self.Tag()
+	return isTag && tag == LATEST_BLOCK_TAG
+}
+
+func (self BlockRef) String() string {
+	if self.hash != nil {
+		return self.hash.Hex()
+	}
+	if self.number != nil {
+		return self.number.String()
+	}
+	tag, _ := self.Tag()
+	return tag
+}
+
+// MarshalJSON encodes the ref as the block parameter of the eth_ JSON-RPC
+// methods
+func (self BlockRef) MarshalJSON() ([]byte, error) {
+	if self.hash != nil {
+		return json.Marshal(map[string]interface{}{
+			"blockHash":        self.hash,
+			"requireCanonical": self.requireCanonical,
+		})
+	}
+	if self.number != nil {
+		return json.Marshal((*hexutil.Big)(self.number))
+	}
+	tag, _ := self.Tag()
+	return json.Marshal(tag)
+}
@@ -0,0 +1,146 @@
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	eu "github.com/tranvictor/ethutils"
+	"github.com/tranvictor/ethutils/cache"
+)
+
+const (
+	// DEFAULT_FINALITY_DEPTH is the number of blocks on top of a block
+	// after which it is considered final by the cache
+	DEFAULT_FINALITY_DEPTH uint64 = 64
+	// HEAD_REFRESH_INTERVAL is how long the head used to decide finality
+	// is reused before it is read again
+	HEAD_REFRESH_INTERVAL time.Duration = 12 * time.Second
+)
+
+type chainCache struct {
+	store         cache.Cache
+	finalityDepth uint64
+
+	mu        sync.Mutex
+	namespace string
+	head      uint64
+	headAt    time.Time
+}
+
+// SetCache makes the reader keep data that can't change anymore in store:
+// receipts, mined txs and headers of blocks with at least finalityDepth
+// blocks on top of them, and code, storage, balances, nonces and
+// eth_call results at such blocks or at a block hash. Keys are prefixed
+// by the chain ID so a store can be shared by readers of different
+// chains. A nil store disables the cache.
+func (self *EthReader) SetCache(store cache.Cache, finalityDepth uint64) {
+	if store == nil {
+		self.cache = nil
+		return
+	}
+	self.cache = &chainCache{
+		store:         store,
+		finalityDepth: finalityDepth,
+	}
+}
+
+// isFinal returns true if the block has at least finalityDepth blocks on
+// top of it. A head that is not fresh anymore is a lower bound of the
+// real one so it is only read again when it can't prove finality.
+func (self *EthReader) isFinal(ctx context.Context, number uint64) bool {
+	c := self.cache
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	head, headAt := c.head, c.headAt
+	c.mu.Unlock()
+	if head >= number+c.finalityDepth {
+		return true
+	}
+	if time.Since(headAt) < HEAD_REFRESH_INTERVAL {
+		return false
+	}
+	head, err := self.CurrentBlockContext(ctx)
+	if err != nil {
+		return false
+	}
+	c.mu.Lock()
+	if head > c.head {
+		c.head = head
+	}
+	c.headAt = time.Now()
+	c.mu.Unlock()
+	return head >= number+c.finalityDepth
+}
+
+// cacheKey returns "" if the cache is disabled or the chain ID can't be
+// read
+func (self *EthReader) cacheKey(ctx context.Context, kind string, parts ...string) string {
+	c := self.cache
+	if c == nil {
+		return ""
+	}
+	c.mu.Lock()
+	namespace := c.namespace
+	c.mu.Unlock()
+	if namespace == "" {
+		chainID, err := self.ChainIDContext(ctx)
+		if err != nil {
+			return ""
+		}
+		namespace = fmt.Sprintf("%d", chainID)
+		c.mu.Lock()
+		c.namespace = namespace
+		c.mu.Unlock()
+	}
+	return strings.ToLower(strings.Join(append([]string{namespace, kind}, parts...), "/"))
+}
+
+func (self *EthReader) txCacheKey(ctx context.Context, kind string, txHash string) string {
+	return self.cacheKey(ctx, kind, eu.HexToHash(txHash).Hex())
+}
+
+// stateCacheKey returns "" unless the state at block can't change: block
+// is a hash or a final block number
+func (self *EthReader) stateCacheKey(ctx context.Context, block BlockRef, kind string, parts ...string) string {
+	if self.cache == nil {
+		return ""
+	}
+	if hash, isHash := block.Hash(); isHash {
+		return self.cacheKey(ctx, kind, append([]string{hash.Hex()}, parts...)...)
+	}
+	number, isNumber := block.Number()
+	if !isNumber || !number.IsUint64() || !self.isFinal(ctx, number.Uint64()) {
+		return ""
+	}
+	return self.cacheKey(ctx, kind, append([]string{number.String()}, parts...)...)
+}
+
+// cached decodes the value stored at key into value. If key is "" or not
+// in the cache, it calls read which must set value, and stores value if
+// read says it is final.
+func (self *EthReader) cached(key string, value interface{}, read func() (final bool, err error)) error {
+	if key == "" {
+		_, err := read()
+		return err
+	}
+	if data, found := self.cache.store.Get(key); found {
+		if json.Unmarshal(data, value) == nil {
+			return nil
+		}
+	}
+	final, err := read()
+	if err != nil || !final {
+		return err
+	}
+	data, err := json.Marshal(value)
+	if err == nil {
+		self.cache.store.Set(key, data)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	eu "github.com/tranvictor/ethutils"
+	"github.com/tranvictor/ethutils/cache"
+)
+
+// cachingReader returns a reader with one fake node at head and a cache
+// with a finality depth of 10 blocks
+func cachingReader(head uint64) (*EthReader, *fakeNode, *cache.LRUCache) {
+	n := newFakeNode("node", head)
+	r := newFakeReader(n)
+	store := cache.NewLRUCache(100)
+	r.SetCache(store, 10)
+	return r, n, store
+}
+
+// advanceHead moves the head of n and makes the reader read it again
+func advanceHead(r *EthReader, n *fakeNode, head uint64) {
+	atomic.StoreUint64(&n.head, head)
+	r.cache.mu.Lock()
+	r.cache.headAt = time.Time{}
+	r.cache.mu.Unlock()
+}
+
+func TestStateCacheKey(t *testing.T) {
+	hash := common.HexToHash("0xabc")
+	cases := []struct {
+		name  string
+		block BlockRef
+		want  string
+	}{
+		{"old number", BlockNumber(10), "1/balance/10/0xab"},
+		{"number at the finality depth", BlockNumber(90), "1/balance/90/0xab"},
+		{"number not final yet", BlockNumber(91), ""},
+		{"number after the head", BlockNumber(200), ""},
+		{"block hash", BlockHash(hash, false), "1/balance/" + hash.Hex() + "/0xab"},
+		{"latest", LatestBlock(), ""},
+		{"pending", PendingBlock(), ""},
+		{"safe", SafeBlock(), ""},
+		{"finalized", FinalizedBlock(), ""},
+	}
+	for _, c := range cases {
+		r, _, _ := cachingReader(100)
+		if got := r.stateCacheKey(context.Background(), c.block, "balance", "0xAB"); got != c.want {
+			t.Errorf("%s: stateCacheKey = %q, want %q", c.name, got, c.want)
+		}
+	}
+
+	r := NewEthReaderGeneric(map[string]string{}, nil)
+	if got := r.stateCacheKey(context.Background(), BlockNumber(1), "balance", "0xab"); got != "" {
+		t.Errorf("stateCacheKey without cache = %q", got)
+	}
+}
+
+func TestReceiptsAreCachedOnceFinal(t *testing.T) {
+	r, n, store := cachingReader(100)
+	reads := 0
+	n.receipt = func(txHash string) (*types.Receipt, error) {
+		reads += 1
+		return &types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			TxHash:      common.HexToHash(txHash),
+			BlockNumber: big.NewInt(95),
+			Logs:        []*types.Log{},
+		}, nil
+	}
+	txHash := common.HexToHash("0x01").Hex()
+	for i := 0; i < 2; i++ {
+		if _, err := r.TransactionReceipt(txHash); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if reads != 2 || store.Len() != 0 {
+		t.Fatalf("a receipt of a non final block was cached: %d reads, %d cached", reads, store.Len())
+	}
+
+	advanceHead(r, n, 105)
+	for i := 0; i < 3; i++ {
+		receipt, err := r.TransactionReceipt(txHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if receipt.TxHash.Hex() != txHash || receipt.BlockNumber.Int64() != 95 {
+			t.Fatalf("cached receipt is %+v", receipt)
+		}
+	}
+	if reads != 3 || store.Len() != 1 {
+		t.Errorf("the final receipt was not cached: %d reads, %d cached", reads, store.Len())
+	}
+}
+
+func TestTxsAreCachedOnceFinal(t *testing.T) {
+	r, n, store := cachingReader(100)
+	key, _ := crypto.GenerateKey()
+	signed, err := types.SignTx(
+		types.NewTransaction(1, common.HexToAddress("0x01"), big.NewInt(1), 21000, big.NewInt(1), nil),
+		types.HomesteadSigner{}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reads := 0
+	var blockNumber *string
+	n.tx = func(txHash string) (*eu.Transaction, bool, error) {
+		reads += 1
+		return &eu.Transaction{
+			Transaction: signed,
+			Extra:       eu.TxExtraInfo{BlockNumber: blockNumber},
+		}, blockNumber == nil, nil
+	}
+	read := func() {
+		tx, _, err := r.TransactionByHash(signed.Hash().Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx.Hash() != signed.Hash() {
+			t.Fatalf("got tx %s, want %s", tx.Hash().Hex(), signed.Hash().Hex())
+		}
+	}
+
+	steps := []struct {
+		name       string
+		block      uint64
+		head       uint64
+		wantReads  int
+		wantCached int
+	}{
+		{"pending", 0, 100, 1, 0},
+		{"not final", 95, 100, 2, 0},
+		{"final", 95, 105, 3, 1},
+		{"from the cache", 95, 105, 3, 1},
+	}
+	for _, step := range steps {
+		if step.block > 0 {
+			number := hexutil.EncodeUint64(step.block)
+			blockNumber = &number
+		}
+		advanceHead(r, n, step.head)
+		read()
+		if reads != step.wantReads || store.Len() != step.wantCached {
+			t.Errorf("%s: %d reads and %d cached, want %d and %d", step.name, reads, store.Len(), step.wantReads, step.wantCached)
+		}
+	}
+}
+
+func TestCachedHeaderDoesNotReadTheHead(t *testing.T) {
+	r, n, store := cachingReader(100)
+	n.header = func(number int64) (*types.Header, error) {
+		return &types.Header{Number: big.NewInt(number), Difficulty: big.NewInt(1)}, nil
+	}
+	if _, err := r.HeaderByNumber(50); err != nil {
+		t.Fatal(err)
+	}
+	if store.Len() != 1 {
+		t.Fatalf("the final header was not cached")
+	}
+
+	// a new reader sharing the store doesn't know the head yet
+	other := newFakeNode("other", 100)
+	other.header = func(number int64) (*types.Header, error) {
+		return nil, fmt.Errorf("header read from the node")
+	}
+	fresh := newFakeReader(other)
+	fresh.SetCache(store, 10)
+	header, err := fresh.HeaderByNumber(50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Number.Int64() != 50 {
+		t.Fatalf("got header %d", header.Number.Int64())
+	}
+	if reads := atomic.LoadInt32(&other.headReads); reads != 0 {
+		t.Errorf("reading a cached header read the head %d times", reads)
+	}
+}
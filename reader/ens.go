@@ -0,0 +1,214 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	eu "github.com/tranvictor/ethutils"
+)
+
+// ErrNoPrimaryName is wrapped by the LookupAddress errors when the
+// address has no reverse record or its reverse record doesn't resolve
+// back to it
+var ErrNoPrimaryName = errors.New("no primary ENS name")
+
+// SetENSRegistry changes the ENS registry used to resolve names. It is
+// only needed for chains where the registry is not deployed at
+// ethutils.ENS_REGISTRY_ADDRESS.
+func (self *EthReader) SetENSRegistry(registry string) {
+	self.ensRegistry = registry
+}
+
+func (self *EthReader) ensResolver(ctx context.Context, block BlockRef, node common.Hash) (common.Address, error) {
+	data, err := self.readContractToBytesAt(ctx,
+		block, DEFAULT_ADDRESS, self.ensRegistry, eu.GetENSRegistryABI(), "resolver", node)
+	if err != nil {
+		return common.Address{}, err
+	}
+	result := common.Address{}
+	if err = eu.GetENSRegistryABI().UnpackIntoInterface(&result, "resolver", data); err != nil {
+		return common.Address{}, fmt.Errorf("couldn't read ENS registry at %s: %s", self.ensRegistry, err)
+	}
+	return result, nil
+}
+
+// ResolveENSNameAt returns the address an ENS name points to at the
+// given block
+func (self *EthReader) ResolveENSNameAt(block BlockRef, name string) (common.Address, error) {
+	return self.ResolveENSNameAtContext(context.Background(), block, name)
+}
+
+func (self *EthReader) ResolveENSNameAtContext(ctx context.Context, block BlockRef, name string) (common.Address, error) {
+	node := eu.NameHash(name)
+	resolver, err := self.ensResolver(ctx, block, node)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if resolver == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("ENS name %s has no resolver", name)
+	}
+	resolverABI := eu.GetENSResolverABI()
+	data, err := self.readContractToBytesAt(ctx,
+		block, DEFAULT_ADDRESS, resolver.Hex(), resolverABI, "addr", node)
+	if err != nil {
+		return common.Address{}, err
+	}
+	result := common.Address{}
+	if err = resolverABI.UnpackIntoInterface(&result, "addr", data); err != nil {
+		return common.Address{}, fmt.Errorf("couldn't read ENS resolver %s: %s", resolver.Hex(), err)
+	}
+	if result == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("ENS name %s doesn't resolve to any address", name)
+	}
+	return result, nil
+}
+
+func (self *EthReader) ResolveENSName(name string) (common.Address, error) {
+	return self.ResolveENSNameContext(context.Background(), name)
+}
+
+func (self *EthReader) ResolveENSNameContext(ctx context.Context, name string) (common.Address, error) {
+	return self.ResolveENSNameAtContext(ctx, LatestBlock(), name)
+}
+
+// LookupAddressAt returns the primary ENS name of an address at the
+// given block using its addr.reverse record. The name is only returned
+// if it resolves back to the address, otherwise the error wraps
+// ErrNoPrimaryName.
+func (self *EthReader) LookupAddressAt(block BlockRef, address string) (string, error) {
+	return self.LookupAddressAtContext(context.Background(), block, address)
+}
+
+func (self *EthReader) LookupAddressAtContext(ctx context.Context, block BlockRef, address string) (string, error) {
+	addr := common.HexToAddress(address)
+	node := eu.NameHash(eu.ReverseENSName(addr))
+	resolver, err := self.ensResolver(ctx, block, node)
+	if err != nil {
+		return "", err
+	}
+	if resolver == (common.Address{}) {
+		return "", fmt.Errorf("%s has no reverse record: %w", addr.Hex(), ErrNoPrimaryName)
+	}
+	resolverABI := eu.GetENSResolverABI()
+	data, err := self.readContractToBytesAt(ctx,
+		block, DEFAULT_ADDRESS, resolver.Hex(), resolverABI, "name", node)
+	if err != nil {
+		return "", err
+	}
+	var name string
+	if err = resolverABI.UnpackIntoInterface(&name, "name", data); err != nil {
+		return "", fmt.Errorf("couldn't read ENS resolver %s: %s", resolver.Hex(), err)
+	}
+	if name == "" {
+		return "", fmt.Errorf("%s has no reverse record: %w", addr.Hex(), ErrNoPrimaryName)
+	}
+	forward, err := self.ResolveENSNameAtContext(ctx, block, name)
+	if err != nil {
+		return "", fmt.Errorf("reverse record %s of %s doesn't resolve: %s", name, addr.Hex(), err)
+	}
+	if forward != addr {
+		return "", fmt.Errorf("reverse record %s of %s resolves to %s: %w", name, addr.Hex(), forward.Hex(), ErrNoPrimaryName)
+	}
+	return name, nil
+}
+
+func (self *EthReader) LookupAddress(address string) (string, error) {
+	return self.LookupAddressContext(context.Background(), address)
+}
+
+func (self *EthReader) LookupAddressContext(ctx context.Context, address string) (string, error) {
+	return self.LookupAddressAtContext(ctx, LatestBlock(), address)
+}
+
+// ResolveAddressAt returns the hex form of nameOrAddress. Hex
+// addresses are returned as is without any network call, ENS names are
+// resolved at the given block. Hex addresses are not validated, use
+// ResolveAddressStrictAt for user input.
+func (self *EthReader) ResolveAddressAt(block BlockRef, nameOrAddress string) (string, error) {
+	return self.ResolveAddressAtContext(context.Background(), block, nameOrAddress)
+}
+
+func (self *EthReader) ResolveAddressAtContext(ctx context.Context, block BlockRef, nameOrAddress string) (string, error) {
+	if nameOrAddress == "" || common.IsHexAddress(nameOrAddress) {
+		return nameOrAddress, nil
+	}
+	if !eu.IsENSName(nameOrAddress) {
+		return "", fmt.Errorf("%s is neither an address nor an ENS name", nameOrAddress)
+	}
+	addr, err := self.ResolveENSNameAtContext(ctx, block, nameOrAddress)
+	if err != nil {
+		return "", err
+	}
+	return addr.Hex(), nil
+}
+
+// ResolveAddressStrictAt returns the checksummed hex form of
+// nameOrAddress. Strings without a dot are validated as hex addresses
+// with ethutils.ParseAddress without any network call, others are
+// resolved as ENS names at the given block.
+func (self *EthReader) ResolveAddressStrictAt(block BlockRef, nameOrAddress string) (string, error) {
+	return self.ResolveAddressStrictAtContext(context.Background(), block, nameOrAddress)
+}
+
+func (self *EthReader) ResolveAddressStrictAtContext(ctx context.Context, block BlockRef, nameOrAddress string) (string, error) {
+	if !strings.Contains(nameOrAddress, ".") {
+		addr, err := eu.ParseAddress(nameOrAddress)
+		if err != nil {
+			return "", err
+		}
+		return addr.Hex(), nil
+	}
+	if !eu.IsENSName(nameOrAddress) {
+		return "", fmt.Errorf("%s is not a valid ENS name", nameOrAddress)
+	}
+	addr, err := self.ResolveENSNameAtContext(ctx, block, nameOrAddress)
+	if err != nil {
+		return "", err
+	}
+	return addr.Hex(), nil
+}
+
+// ResolveAddressStrict works as ResolveAddressStrictAt at the latest block
+func (self *EthReader) ResolveAddressStrict(nameOrAddress string) (string, error) {
+	return self.ResolveAddressStrictContext(context.Background(), nameOrAddress)
+}
+
+func (self *EthReader) ResolveAddressStrictContext(ctx context.Context, nameOrAddress string) (string, error) {
+	return self.ResolveAddressStrictAtContext(ctx, LatestBlock(), nameOrAddress)
+}
+
+// ResolveAddress works as ResolveAddressAt at the latest block
+func (self *EthReader) ResolveAddress(nameOrAddress string) (string, error) {
+	return self.ResolveAddressContext(context.Background(), nameOrAddress)
+}
+
+func (self *EthReader) ResolveAddressContext(ctx context.Context, nameOrAddress string) (string, error) {
+	return self.ResolveAddressAtContext(ctx, LatestBlock(), nameOrAddress)
+}
+
+func (self *EthReader) resolveAddressesAt(ctx context.Context, block BlockRef, namesOrAddresses []string) ([]string, error) {
+	result := []string{}
+	for _, a := range namesOrAddresses {
+		addr, err := self.ResolveAddressAtContext(ctx, block, a)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, addr)
+	}
+	return result, nil
+}
+
+func (self *EthReader) resolvePairAt(ctx context.Context, block BlockRef, a, b string) (string, string, error) {
+	a, err := self.ResolveAddressAtContext(ctx, block, a)
+	if err != nil {
+		return "", "", err
+	}
+	b, err = self.ResolveAddressAtContext(ctx, block, b)
+	if err != nil {
+		return "", "", err
+	}
+	return a, b, nil
+}
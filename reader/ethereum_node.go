@@ -1,27 +1,86 @@
 package reader
 
 import (
+	"context"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	eu "github.com/tranvictor/ethutils"
 )
 
+// EthereumNode is a single node EthReader reads from
 type EthereumNode interface {
 	NodeName() string
 	NodeURL() string
 	EstimateGas(from, to string, priceGwei float64, value *big.Int, data []byte) (gas uint64, err error)
+	CreateAccessList(from, to string, priceGwei float64, value *big.Int, data []byte) (accessList types.AccessList, gasUsed uint64, err error)
 	GetCode(address string) (code []byte, err error)
 	GetBalance(address string) (balance *big.Int, err error)
 	GetMinedNonce(address string) (nonce uint64, err error)
 	GetPendingNonce(address string) (nonce uint64, err error)
+	GetBalanceAt(address string, block BlockRef) (balance *big.Int, err error)
+	GetCodeAt(address string, block BlockRef) (code []byte, err error)
+	GetNonceAt(address string, block BlockRef) (nonce uint64, err error)
+	GetStorageAt(address string, slot common.Hash, block BlockRef) (value common.Hash, err error)
+	CallContractAt(block BlockRef, from string, caddr string, data []byte) ([]byte, error)
 	TransactionReceipt(txHash string) (receipt *types.Receipt, err error)
 	TransactionByHash(txHash string) (tx *eu.Transaction, isPending bool, err error)
 	// Call(result interface{}, method string, args ...interface{}) error
 	GetGasPriceSuggestion() (*big.Int, error)
+	GetGasTipCapSuggestion() (*big.Int, error)
+	ChainID() (int64, error)
 	ReadContractToBytes(atBlock int64, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) ([]byte, error)
 	HeaderByNumber(number int64) (*types.Header, error)
 	GetLogs(fromBlock, toBlock int, addresses []string, topic string) ([]types.Log, error)
+	FilterLogs(q ethereum.FilterQuery) ([]types.Log, error)
 	CurrentBlock() (uint64, error)
+	// IsTraceCapable returns true if the node serves debug_traceTransaction
+	IsTraceCapable() bool
+	TraceTransaction(txHash string) (*CallFrame, error)
+	// the Batch methods send the requests in JSON-RPC batches and return
+	// a result and an error per element, the last error is set when the
+	// requests failed as a whole
+	BatchGetBalancesAt(addresses []string, block BlockRef) ([]*big.Int, []error, error)
+	BatchGetNoncesAt(addresses []string, block BlockRef) ([]uint64, []error, error)
+	BatchTransactionReceipts(txHashes []string) ([]*types.Receipt, []error, error)
+	BatchTransactionsByHash(txHashes []string) ([]*eu.Transaction, []error, error)
+	BatchHeadersByNumber(numbers []int64) ([]*types.Header, []error, error)
+}
+
+// ContextEthereumNode is an EthereumNode whose requests can be bounded by a
+// ctx. EthReader reads from its nodes through the Context methods.
+type ContextEthereumNode interface {
+	EthereumNode
+
+	EstimateGasContext(ctx context.Context, from, to string, priceGwei float64, value *big.Int, data []byte) (gas uint64, err error)
+	CreateAccessListContext(ctx context.Context, from, to string, priceGwei float64, value *big.Int, data []byte) (accessList types.AccessList, gasUsed uint64, err error)
+	EstimateGasWithAccessListContext(ctx context.Context, from, to string, priceGwei float64, value *big.Int, data []byte, accessList types.AccessList) (gas uint64, err error)
+	GetCodeContext(ctx context.Context, address string) (code []byte, err error)
+	GetBalanceContext(ctx context.Context, address string) (balance *big.Int, err error)
+	GetMinedNonceContext(ctx context.Context, address string) (nonce uint64, err error)
+	GetPendingNonceContext(ctx context.Context, address string) (nonce uint64, err error)
+	GetBalanceAtContext(ctx context.Context, address string, block BlockRef) (balance *big.Int, err error)
+	GetCodeAtContext(ctx context.Context, address string, block BlockRef) (code []byte, err error)
+	GetNonceAtContext(ctx context.Context, address string, block BlockRef) (nonce uint64, err error)
+	GetStorageAtContext(ctx context.Context, address string, slot common.Hash, block BlockRef) (value common.Hash, err error)
+	CallContractAtContext(ctx context.Context, block BlockRef, from string, caddr string, data []byte) ([]byte, error)
+	TransactionReceiptContext(ctx context.Context, txHash string) (receipt *types.Receipt, err error)
+	TransactionByHashContext(ctx context.Context, txHash string) (tx *eu.Transaction, isPending bool, err error)
+	GetGasPriceSuggestionContext(ctx context.Context) (*big.Int, error)
+	GetGasTipCapSuggestionContext(ctx context.Context) (*big.Int, error)
+	ChainIDContext(ctx context.Context) (int64, error)
+	ReadContractToBytesContext(ctx context.Context, atBlock int64, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) ([]byte, error)
+	HeaderByNumberContext(ctx context.Context, number int64) (*types.Header, error)
+	GetLogsContext(ctx context.Context, fromBlock, toBlock int, addresses []string, topic string) ([]types.Log, error)
+	FilterLogsContext(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	CurrentBlockContext(ctx context.Context) (uint64, error)
+	TraceTransactionContext(ctx context.Context, txHash string) (*CallFrame, error)
+	BatchGetBalancesAtContext(ctx context.Context, addresses []string, block BlockRef) ([]*big.Int, []error, error)
+	BatchGetNoncesAtContext(ctx context.Context, addresses []string, block BlockRef) ([]uint64, []error, error)
+	BatchTransactionReceiptsContext(ctx context.Context, txHashes []string) ([]*types.Receipt, []error, error)
+	BatchTransactionsByHashContext(ctx context.Context, txHashes []string) ([]*eu.Transaction, []error, error)
+	BatchHeadersByNumberContext(ctx context.Context, numbers []int64) ([]*types.Header, []error, error)
 }
@@ -0,0 +1,247 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DecodedLog is a log decoded with the ABI of the contract emitting it
+type DecodedLog struct {
+	Event string
+	// Args maps the argument names to their values, indexed arguments
+	// included. Indexed strings, bytes, arrays and slices are only kept
+	// as their keccak256 hash in the topics so their value is a
+	// common.Hash. Unnamed arguments are named arg0, arg1... by position.
+	Args map[string]interface{}
+	Log  types.Log
+}
+
+// decodeEventArgs returns the values of the arguments of event in the
+// order of event.Inputs
+func decodeEventArgs(event *abi.Event, l types.Log) ([]interface{}, error) {
+	topics := l.Topics
+	if !event.Anonymous {
+		if len(topics) == 0 || topics[0] != event.ID {
+			return nil, fmt.Errorf("log is not a %s event", event.Name)
+		}
+		topics = topics[1:]
+	}
+	nonIndexed := event.Inputs.NonIndexed()
+	data := []interface{}{}
+	if len(nonIndexed) > 0 {
+		var err error
+		data, err = nonIndexed.UnpackValues(l.Data)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode the data of %s: %s", event.Name, err)
+		}
+	}
+	result := []interface{}{}
+	for _, arg := range event.Inputs {
+		if !arg.Indexed {
+			result = append(result, data[0])
+			data = data[1:]
+			continue
+		}
+		if len(topics) == 0 {
+			return nil, fmt.Errorf("%s has more indexed arguments than the log has topics", event.Name)
+		}
+		value := map[string]interface{}{}
+		err := abi.ParseTopicsIntoMap(value, abi.Arguments{arg}, topics[:1])
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode the topic %s of %s: %s", arg.Name, event.Name, err)
+		}
+		result = append(result, value[arg.Name])
+		topics = topics[1:]
+	}
+	if len(topics) != 0 {
+		return nil, fmt.Errorf("log has more topics than %s has indexed arguments", event.Name)
+	}
+	return result, nil
+}
+
+func argName(arg abi.Argument, position int) string {
+	if arg.Name == "" {
+		return fmt.Sprintf("arg%d", position)
+	}
+	return arg.Name
+}
+
+// DecodeLog finds the event of l in a by its first topic and decodes all
+// of its arguments. Anonymous events can't be found this way, use
+// DecodeEvent for them.
+func DecodeLog(a *abi.ABI, l types.Log) (*DecodedLog, error) {
+	if len(l.Topics) == 0 {
+		return nil, fmt.Errorf("log has no topic to find its event")
+	}
+	event, err := a.EventByID(l.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+	values, err := decodeEventArgs(event, l)
+	if err != nil {
+		return nil, err
+	}
+	result := &DecodedLog{
+		Event: event.Name,
+		Args:  map[string]interface{}{},
+		Log:   l,
+	}
+	for i, arg := range event.Inputs {
+		result.Args[argName(arg, i)] = values[i]
+	}
+	return result, nil
+}
+
+// DecodeEvent decodes l, a log of the event named name in a, into result
+// which must be a pointer to a struct. An argument goes to the field
+// tagged with `abi:"<argument name>"` or else to the field named as the
+// argument in camel case, as abigen does. Arguments without a field are
+// skipped. A field named Raw of type types.Log gets the log itself.
+func DecodeEvent(a *abi.ABI, name string, l types.Log, result interface{}) error {
+	event, found := a.Events[name]
+	if !found {
+		return fmt.Errorf("abi has no event named %s", name)
+	}
+	values, err := decodeEventArgs(&event, l)
+	if err != nil {
+		return err
+	}
+	out := reflect.ValueOf(result)
+	if out.Kind() != reflect.Ptr || out.IsNil() || out.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("result must be a pointer to a struct, got %T", result)
+	}
+	return setEventFields(out.Elem(), &event, values, l)
+}
+
+// setEventFields sets the fields of the struct out to the decoded values
+// of the arguments of event as described in DecodeEvent
+func setEventFields(out reflect.Value, event *abi.Event, values []interface{}, l types.Log) error {
+	for i, arg := range event.Inputs {
+		field := structField(out, argName(arg, i))
+		if !field.IsValid() {
+			continue
+		}
+		if err := setField(field, values[i]); err != nil {
+			return fmt.Errorf("couldn't set %s: %s", argName(arg, i), err)
+		}
+	}
+	if raw := out.FieldByName("Raw"); raw.IsValid() && raw.CanSet() && raw.Type() == reflect.TypeOf(l) {
+		raw.Set(reflect.ValueOf(l))
+	}
+	return nil
+}
+
+func structField(out reflect.Value, name string) reflect.Value {
+	t := out.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("abi") == name {
+			return out.Field(i)
+		}
+	}
+	return out.FieldByName(abi.ToCamelCase(name))
+}
+
+func setField(field reflect.Value, value interface{}) error {
+	if !field.CanSet() {
+		return fmt.Errorf("field is not exported")
+	}
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Type().AssignableTo(field.Type()):
+		field.Set(v)
+	case v.Kind() == field.Kind() && v.Type().ConvertibleTo(field.Type()):
+		field.Set(v.Convert(field.Type()))
+	case v.Kind() == reflect.Ptr && v.Elem().Type().AssignableTo(field.Type()):
+		// *big.Int into a big.Int field
+		field.Set(v.Elem())
+	default:
+		return fmt.Errorf("can't set %s to a field of type %s", v.Type(), field.Type())
+	}
+	return nil
+}
+
+func (self *EthReader) FilterDecodedLogs(q ethereum.FilterQuery, a *abi.ABI) ([]DecodedLog, error) {
+	return self.FilterDecodedLogsContext(context.Background(), q, a)
+}
+
+// FilterDecodedLogsContext returns the logs matching q decoded with a.
+// Logs of events that are not in a are skipped, as are logs that don't
+// decode with the event of their first topic, such as an ERC721 Transfer
+// read with the ERC20 ABI.
+func (self *EthReader) FilterDecodedLogsContext(ctx context.Context, q ethereum.FilterQuery, a *abi.ABI) ([]DecodedLog, error) {
+	logs, err := self.FilterLogsContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	result := []DecodedLog{}
+	for _, l := range logs {
+		decoded, err := DecodeLog(a, l)
+		if err != nil {
+			continue
+		}
+		result = append(result, *decoded)
+	}
+	return result, nil
+}
+
+func (self *EthReader) FilterEvents(q ethereum.FilterQuery, a *abi.ABI, name string, result interface{}) error {
+	return self.FilterEventsContext(context.Background(), q, a, name, result)
+}
+
+// FilterEventsContext appends the events named name matching q to result,
+// a pointer to a slice of structs or of pointers to structs which are
+// filled as DecodeEvent does. Logs that don't decode as the event are
+// skipped. When the first position of q.Topics is empty, it is set to
+// the event ID so only that event is read.
+func (self *EthReader) FilterEventsContext(ctx context.Context, q ethereum.FilterQuery, a *abi.ABI, name string, result interface{}) error {
+	event, found := a.Events[name]
+	if !found {
+		return fmt.Errorf("abi has no event named %s", name)
+	}
+	out := reflect.ValueOf(result)
+	if out.Kind() != reflect.Ptr || out.IsNil() || out.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("result must be a pointer to a slice, got %T", result)
+	}
+	out = out.Elem()
+	elemType := out.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("result must be a slice of structs, got %T", result)
+	}
+	if !event.Anonymous && (len(q.Topics) == 0 || len(q.Topics[0]) == 0) {
+		topics := [][]common.Hash{{event.ID}}
+		if len(q.Topics) > 0 {
+			topics = append(topics, q.Topics[1:]...)
+		}
+		q.Topics = topics
+	}
+	logs, err := self.FilterLogsContext(ctx, q)
+	if err != nil {
+		return err
+	}
+	for _, l := range logs {
+		values, err := decodeEventArgs(&event, l)
+		if err != nil {
+			continue
+		}
+		elem := reflect.New(elemType)
+		if err := setEventFields(elem.Elem(), &event, values, l); err != nil {
+			return err
+		}
+		if isPtr {
+			out.Set(reflect.Append(out, elem))
+		} else {
+			out.Set(reflect.Append(out, elem.Elem()))
+		}
+	}
+	return nil
+}
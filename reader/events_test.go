@@ -0,0 +1,154 @@
+package reader
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const eventsTestABI = `[
+  {"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"},
+  {"anonymous":false,"inputs":[{"indexed":true,"name":"name","type":"string"},{"indexed":true,"name":"ids","type":"uint256[]"},{"indexed":false,"name":"","type":"bytes"}],"name":"Registered","type":"event"}
+]`
+
+var (
+	alice = common.HexToAddress("0x00000000000000000000000000000000000a11ce")
+	bob   = common.HexToAddress("0x0000000000000000000000000000000000000b0b")
+)
+
+func eventsABI(t *testing.T) abi.ABI {
+	a, err := abi.JSON(strings.NewReader(eventsTestABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func transferLog(a abi.ABI, from, to common.Address, value int64) types.Log {
+	data, _ := a.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(value))
+	return types.Log{
+		Topics: []common.Hash{
+			a.Events["Transfer"].ID,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data:        data,
+		BlockNumber: 10,
+	}
+}
+
+func TestDecodeLog(t *testing.T) {
+	a := eventsABI(t)
+	nameHash := crypto.Keccak256Hash([]byte("alice.eth"))
+	idsHash := common.HexToHash("0x1234")
+	registeredData, _ := a.Events["Registered"].Inputs.NonIndexed().Pack([]byte{1, 2})
+	withExtraTopic := transferLog(a, alice, bob, 5)
+	withExtraTopic.Topics = append(withExtraTopic.Topics, common.HexToHash("0x01"))
+	missingTopic := transferLog(a, alice, bob, 5)
+	missingTopic.Topics = missingTopic.Topics[:2]
+
+	cases := []struct {
+		name  string
+		log   types.Log
+		event string
+		args  map[string]interface{}
+		fails bool
+	}{
+		{
+			name:  "erc20 transfer",
+			log:   transferLog(a, alice, bob, 1000),
+			event: "Transfer",
+			args:  map[string]interface{}{"from": alice, "to": bob, "value": big.NewInt(1000)},
+		},
+		{
+			name: "indexed dynamic args are hashes",
+			log: types.Log{
+				Topics: []common.Hash{a.Events["Registered"].ID, nameHash, idsHash},
+				Data:   registeredData,
+			},
+			event: "Registered",
+			args:  map[string]interface{}{"name": nameHash, "ids": idsHash, "arg2": []byte{1, 2}},
+		},
+		{name: "extra topic", log: withExtraTopic, fails: true},
+		{name: "missing topic", log: missingTopic, fails: true},
+		{name: "unknown event", log: types.Log{Topics: []common.Hash{common.HexToHash("0x01")}}, fails: true},
+		{name: "no topic", log: types.Log{}, fails: true},
+	}
+	for _, c := range cases {
+		decoded, err := DecodeLog(&a, c.log)
+		if c.fails {
+			if err == nil {
+				t.Errorf("%s: decoded %v, want an error", c.name, decoded.Args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		if decoded.Event != c.event || !reflect.DeepEqual(decoded.Args, c.args) {
+			t.Errorf("%s: got %s %v, want %s %v", c.name, decoded.Event, decoded.Args, c.event, c.args)
+		}
+	}
+}
+
+func TestDecodeEvent(t *testing.T) {
+	a := eventsABI(t)
+	l := transferLog(a, alice, bob, 1000)
+
+	type transfer struct {
+		From  common.Address
+		To    common.Address
+		Value *big.Int
+		Raw   types.Log
+	}
+	got := transfer{}
+	if err := DecodeEvent(&a, "Transfer", l, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := transfer{alice, bob, big.NewInt(1000), l}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// tags win over names, missing fields are skipped and *big.Int goes
+	// into big.Int
+	type tagged struct {
+		Sender common.Address `abi:"from"`
+		From   common.Address
+		Amount big.Int `abi:"value"`
+	}
+	gotTagged := tagged{}
+	if err := DecodeEvent(&a, "Transfer", l, &gotTagged); err != nil {
+		t.Fatal(err)
+	}
+	if gotTagged.Sender != alice || gotTagged.From != (common.Address{}) || gotTagged.Amount.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("got %+v", gotTagged)
+	}
+
+	type wrongType struct {
+		Value string
+	}
+	invalid := []struct {
+		name   string
+		event  string
+		result interface{}
+	}{
+		{"not a pointer", "Transfer", transfer{}},
+		{"not a struct", "Transfer", new(int)},
+		{"wrong field type", "Transfer", &wrongType{}},
+		{"unknown event", "Approval", &transfer{}},
+		{"other event", "Registered", &transfer{}},
+	}
+	for _, c := range invalid {
+		if err := DecodeEvent(&a, c.event, l, c.result); err == nil {
+			t.Errorf("%s: no error", c.name)
+		}
+	}
+}
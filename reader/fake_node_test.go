@@ -0,0 +1,135 @@
+package reader
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	eu "github.com/tranvictor/ethutils"
+)
+
+// fakeNode is a ContextEthereumNode answering from the funcs set in the
+// tests. The requests without a func go to a OneNodeReader on a closed
+// port and fail.
+type fakeNode struct {
+	*OneNodeReader
+	head uint64
+	// number of eth_calls and head reads
+	calls     int32
+	headReads int32
+
+	call    func(block BlockRef, caddr string, data []byte) ([]byte, error)
+	receipt func(txHash string) (*types.Receipt, error)
+	tx      func(txHash string) (*eu.Transaction, bool, error)
+	header  func(number int64) (*types.Header, error)
+	logs    func(q ethereum.FilterQuery) ([]types.Log, error)
+	balance func(address string, block BlockRef) (*big.Int, error)
+	tip     func() (*big.Int, error)
+}
+
+func newFakeNode(name string, head uint64) *fakeNode {
+	return &fakeNode{
+		OneNodeReader: NewOneNodeReader(name, "http://127.0.0.1:1"),
+		head:          head,
+	}
+}
+
+func newFakeReader(nodes ...ContextEthereumNode) *EthReader {
+	r := NewEthReaderGeneric(map[string]string{}, nil)
+	for _, n := range nodes {
+		r.nodes[n.NodeName()] = n
+	}
+	return r
+}
+
+func (self *fakeNode) CurrentBlockContext(ctx context.Context) (uint64, error) {
+	atomic.AddInt32(&self.headReads, 1)
+	return atomic.LoadUint64(&self.head), nil
+}
+
+func (self *fakeNode) ChainIDContext(ctx context.Context) (int64, error) {
+	return 1, nil
+}
+
+func (self *fakeNode) TransactionReceiptContext(ctx context.Context, txHash string) (*types.Receipt, error) {
+	return self.receipt(txHash)
+}
+
+func (self *fakeNode) TransactionByHashContext(ctx context.Context, txHash string) (*eu.Transaction, bool, error) {
+	return self.tx(txHash)
+}
+
+func (self *fakeNode) HeaderByNumberContext(ctx context.Context, number int64) (*types.Header, error) {
+	return self.header(number)
+}
+
+func (self *fakeNode) GetGasTipCapSuggestionContext(ctx context.Context) (*big.Int, error) {
+	return self.tip()
+}
+
+func (self *fakeNode) CallContractAtContext(ctx context.Context, block BlockRef, from string, caddr string, data []byte) ([]byte, error) {
+	atomic.AddInt32(&self.calls, 1)
+	return self.call(block, caddr, data)
+}
+
+func (self *fakeNode) GetBalanceAtContext(ctx context.Context, address string, block BlockRef) (*big.Int, error) {
+	return self.balance(address, block)
+}
+
+func (self *fakeNode) BatchGetBalancesAtContext(ctx context.Context, addresses []string, block BlockRef) ([]*big.Int, []error, error) {
+	balances := []*big.Int{}
+	errs := []error{}
+	for _, address := range addresses {
+		balance, err := self.balance(address, block)
+		balances = append(balances, balance)
+		errs = append(errs, err)
+	}
+	return balances, errs, nil
+}
+
+// serveENS makes n answer the ENS registry and resolver calls for names
+func (self *fakeNode) serveENS(names map[string]common.Address) {
+	resolver := common.HexToAddress("0x0000000000000000000000000000000000005e50")
+	addresses := map[common.Hash]common.Address{}
+	for name, addr := range names {
+		addresses[eu.NameHash(name)] = addr
+	}
+	self.call = func(block BlockRef, caddr string, data []byte) ([]byte, error) {
+		addr, found := addresses[common.BytesToHash(data[4:36])]
+		switch {
+		case strings.EqualFold(caddr, eu.ENS_REGISTRY_ADDRESS) && found:
+			return common.LeftPadBytes(resolver.Bytes(), 32), nil
+		case strings.EqualFold(caddr, resolver.Hex()):
+			return common.LeftPadBytes(addr.Bytes(), 32), nil
+		}
+		return make([]byte, 32), nil
+	}
+}
+
+// rpcError is an error as returned by a node handling the request
+type rpcError string
+
+func (self rpcError) Error() string  { return string(self) }
+func (self rpcError) ErrorCode() int { return -32000 }
+
+var _ rpc.Error = rpcError("")
+
+func (self *fakeNode) BatchHeadersByNumberContext(ctx context.Context, numbers []int64) ([]*types.Header, []error, error) {
+	headers := []*types.Header{}
+	errs := []error{}
+	for _, number := range numbers {
+		header, err := self.header(number)
+		headers = append(headers, header)
+		errs = append(errs, err)
+	}
+	return headers, errs, nil
+}
+
+func (self *fakeNode) FilterLogsContext(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return self.logs(q)
+}
@@ -0,0 +1,45 @@
+package reader
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestRecommendedDynamicFeesWei(t *testing.T) {
+	cases := []struct {
+		name    string
+		baseFee *big.Int
+		tipCap  string
+		feeCap  string
+		err     bool
+	}{
+		// 2 * base fee + tip, to the wei
+		{"london", big.NewInt(12345678901), "1500000001", "26191357803", false},
+		{"pre london", nil, "", "", true},
+	}
+	for _, c := range cases {
+		n := newFakeNode("node", 100)
+		n.tip = func() (*big.Int, error) {
+			return big.NewInt(1500000001), nil
+		}
+		baseFee := c.baseFee
+		n.header = func(number int64) (*types.Header, error) {
+			return &types.Header{Number: big.NewInt(100), BaseFee: baseFee}, nil
+		}
+		tipCap, feeCap, err := newFakeReader(n).RecommendedDynamicFeesWei()
+		if c.err {
+			if err == nil {
+				t.Errorf("%s: got %s, %s, want an error", c.name, tipCap, feeCap)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: RecommendedDynamicFeesWei failed: %s", c.name, err)
+		}
+		if tipCap.String() != c.tipCap || feeCap.String() != c.feeCap {
+			t.Errorf("%s: got %s, %s, want %s, %s", c.name, tipCap, feeCap, c.tipCap, c.feeCap)
+		}
+	}
+}
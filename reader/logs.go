@@ -0,0 +1,253 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	eu "github.com/tranvictor/ethutils"
+)
+
+// messages of the errors providers return when a log query covers too
+// many blocks or matches too many logs
+var logsRangeErrorMessages = []string{
+	"block range",
+	"range too large",
+	"range is too large",
+	"range limit",
+	"more than 10000 results",
+	"too many results",
+	"query returned more than",
+	"response size exceeded",
+	"response size should not",
+	"is limited to",
+	"logs matched by query exceeds",
+	"max results",
+}
+
+func isLogsRangeError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, m := range logsRangeErrorMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// logsQuery builds the query of the legacy GetLogs methods. A negative
+// toBlock means the latest block and an empty topic matches any event.
+func logsQuery(fromBlock, toBlock int, addresses []string, topic string) ethereum.FilterQuery {
+	q := ethereum.FilterQuery{
+		FromBlock: big.NewInt(int64(fromBlock)),
+		Addresses: eu.HexToAddresses(addresses),
+	}
+	if toBlock >= 0 {
+		q.ToBlock = big.NewInt(int64(toBlock))
+	}
+	if topic != "" {
+		q.Topics = [][]common.Hash{{eu.HexToHash(topic)}}
+	}
+	return q
+}
+
+// TopicsFromHex builds the topic matrix of a FilterQuery. Position i
+// matches any of the hashes of topics[i] and an empty position matches
+// anything, so [][]string{{transfer, approval}, {}, {to}} matches the
+// Transfer and Approval events to the given address. Addresses are left
+// padded to 32 bytes.
+func TopicsFromHex(topics ...[]string) ([][]common.Hash, error) {
+	result := [][]common.Hash{}
+	for i, position := range topics {
+		hashes := []common.Hash{}
+		for _, t := range position {
+			if address, err := eu.ParseAddress(t); err == nil {
+				hashes = append(hashes, common.BytesToHash(address.Bytes()))
+				continue
+			}
+			hash, err := eu.ParseHash(t)
+			if err != nil {
+				return nil, fmt.Errorf("invalid topic %d: %s", i, err)
+			}
+			hashes = append(hashes, hash)
+		}
+		result = append(result, hashes)
+	}
+	return result, nil
+}
+
+type getLogsResponse struct {
+	Logs  []types.Log
+	Error error
+}
+
+func (self *EthReader) FilterLogs(q ethereum.FilterQuery) ([]types.Log, error) {
+	return self.FilterLogsContext(context.Background(), q)
+}
+
+// FilterLogsContext returns the logs matching q from every node, merged,
+// de-duplicated, without the logs of non canonical blocks and sorted by
+// block and log index. When no node can serve a block range because it
+// is too large or matches too many logs, the range is split in halves
+// until the nodes accept it. A nil ToBlock means the current block.
+func (self *EthReader) FilterLogsContext(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if q.BlockHash != nil {
+		return self.filterLogsOnNodes(ctx, q)
+	}
+	from := uint64(0)
+	if q.FromBlock != nil {
+		from = q.FromBlock.Uint64()
+	}
+	var to uint64
+	if q.ToBlock != nil {
+		to = q.ToBlock.Uint64()
+	} else {
+		current, err := self.CurrentBlockContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get the current block: %s", err)
+		}
+		to = current
+	}
+	if from > to {
+		return []types.Log{}, nil
+	}
+	return self.filterLogsRange(ctx, q, from, to)
+}
+
+func (self *EthReader) filterLogsRange(ctx context.Context, q ethereum.FilterQuery, from, to uint64) ([]types.Log, error) {
+	q.FromBlock = new(big.Int).SetUint64(from)
+	q.ToBlock = new(big.Int).SetUint64(to)
+	logs, err := self.filterLogsOnNodes(ctx, q)
+	if err == nil || from == to || !isLogsRangeError(err) {
+		return logs, err
+	}
+	mid := from + (to-from)/2
+	first, err := self.filterLogsRange(ctx, q, from, mid)
+	if err != nil {
+		return nil, err
+	}
+	second, err := self.filterLogsRange(ctx, q, mid+1, to)
+	if err != nil {
+		return nil, err
+	}
+	return append(first, second...), nil
+}
+
+// LOGS_MERGE_WAIT is how long filterLogsOnNodes keeps waiting for the
+// other nodes once one node answered a log query
+const LOGS_MERGE_WAIT time.Duration = time.Second
+
+// filterLogsOnNodes merges the logs of the nodes answering q. Once a
+// node answered, the others get LOGS_MERGE_WAIT more to answer so a slow
+// node delays the query by that much at most. It fails only if no node
+// answered.
+func (self *EthReader) filterLogsOnNodes(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	nodes := self.activeNodes()
+	resCh := make(chan getLogsResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
+		go func() {
+			var logs []types.Log
+			err := self.callNode(ctx, n, func() (err error) {
+				logs, err = n.FilterLogsContext(ctx, q)
+				return err
+			})
+			resCh <- getLogsResponse{
+				Logs:  logs,
+				Error: wrapError(err, n.NodeName()),
+			}
+		}()
+	}
+	errs := []error{}
+	answers := [][]types.Log{}
+	var deadline <-chan time.Time
+collect:
+	for i := 0; i < len(nodes); i++ {
+		var result getLogsResponse
+		select {
+		case result = <-resCh:
+		case <-deadline:
+			break collect
+		}
+		if result.Error != nil {
+			errs = append(errs, result.Error)
+			continue
+		}
+		answers = append(answers, result.Logs)
+		if deadline == nil {
+			timer := time.NewTimer(LOGS_MERGE_WAIT)
+			defer timer.Stop()
+			deadline = timer.C
+		}
+	}
+	if len(answers) == 0 {
+		return nil, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
+	}
+	return self.mergeLogs(ctx, answers)
+}
+
+// mergeLogs de-duplicates the logs the nodes answered and sorts them by
+// block and log index. A block whose logs the nodes don't agree on, such
+// as a block only some nodes have or a block reorged on some of them, is
+// checked against the canonical header of its number and only the logs
+// of that header are kept.
+func (self *EthReader) mergeLogs(ctx context.Context, answers [][]types.Log) ([]types.Log, error) {
+	// how many answers have each block hash at each block number
+	blocks := map[uint64]map[common.Hash]int{}
+	merged := map[string]types.Log{}
+	for _, logs := range answers {
+		seen := map[common.Hash]bool{}
+		for _, l := range logs {
+			if !seen[l.BlockHash] {
+				seen[l.BlockHash] = true
+				if blocks[l.BlockNumber] == nil {
+					blocks[l.BlockNumber] = map[common.Hash]int{}
+				}
+				blocks[l.BlockNumber][l.BlockHash] += 1
+			}
+			merged[fmt.Sprintf("%s-%d", l.BlockHash.Hex(), l.Index)] = l
+		}
+	}
+	numbers := []int64{}
+	for number, hashes := range blocks {
+		for _, count := range hashes {
+			if len(hashes) > 1 || count != len(answers) {
+				numbers = append(numbers, int64(number))
+			}
+			break
+		}
+	}
+	canonical := map[uint64]common.Hash{}
+	if len(numbers) > 0 {
+		headers, errs, err := self.BatchHeadersByNumberContext(ctx, numbers)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read the headers of the blocks nodes disagree on: %s", err)
+		}
+		for i, number := range numbers {
+			if errs[i] != nil {
+				return nil, fmt.Errorf("couldn't read the header of block %d: %s", number, errs[i])
+			}
+			canonical[uint64(number)] = headers[i].Hash()
+		}
+	}
+	logs := make([]types.Log, 0, len(merged))
+	for _, l := range merged {
+		if hash, found := canonical[l.BlockNumber]; found && hash != l.BlockHash {
+			continue
+		}
+		logs = append(logs, l)
+	}
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+	return logs, nil
+}
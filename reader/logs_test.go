@@ -0,0 +1,217 @@
+package reader
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestIsLogsRangeError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{rpcError("query returned more than 10000 results"), true},
+		{rpcError("eth_getLogs block range is too large, max 2000"), true},
+		{rpcError("Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range"), true},
+		{rpcError("exceed maximum block range: 5000"), true},
+		{fmt.Errorf("query timeout exceeded"), false},
+		{rpcError("invalid argument 0: hex string without 0x prefix"), false},
+		{fmt.Errorf("header not found"), false},
+	}
+	for _, c := range cases {
+		if got := isLogsRangeError(c.err); got != c.want {
+			t.Errorf("isLogsRangeError(%q) = %t, want %t", c.err, got, c.want)
+		}
+	}
+}
+
+// rangeLimitedNode has one log per block and rejects queries of more
+// than maxBlocks blocks
+func rangeLimitedNode(name string, maxBlocks uint64) (*fakeNode, *[][2]uint64) {
+	n := newFakeNode(name, 1000)
+	mu := sync.Mutex{}
+	queried := [][2]uint64{}
+	n.logs = func(q ethereum.FilterQuery) ([]types.Log, error) {
+		from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+		mu.Lock()
+		queried = append(queried, [2]uint64{from, to})
+		mu.Unlock()
+		if to-from+1 > maxBlocks {
+			return nil, rpcError(fmt.Sprintf("block range is too large, max %d", maxBlocks))
+		}
+		logs := []types.Log{}
+		for b := from; b <= to; b++ {
+			logs = append(logs, types.Log{BlockNumber: b, BlockHash: common.BigToHash(new(big.Int).SetUint64(b))})
+		}
+		return logs, nil
+	}
+	return n, &queried
+}
+
+func TestFilterLogsSplitsRanges(t *testing.T) {
+	cases := []struct {
+		from, to  uint64
+		maxBlocks uint64
+	}{
+		{100, 100, 1},
+		{100, 109, 10},
+		{100, 199, 10},
+		{100, 200, 7},
+		{0, 1000, 64},
+	}
+	for _, c := range cases {
+		n, queried := rangeLimitedNode("node", c.maxBlocks)
+		r := newFakeReader(n)
+		logs, err := r.FilterLogs(ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(c.from),
+			ToBlock:   new(big.Int).SetUint64(c.to),
+		})
+		if err != nil {
+			t.Errorf("%d-%d by %d: %s", c.from, c.to, c.maxBlocks, err)
+			continue
+		}
+		if uint64(len(logs)) != c.to-c.from+1 {
+			t.Errorf("%d-%d by %d: got %d logs", c.from, c.to, c.maxBlocks, len(logs))
+			continue
+		}
+		for i, l := range logs {
+			if l.BlockNumber != c.from+uint64(i) {
+				t.Errorf("%d-%d by %d: log %d is at block %d", c.from, c.to, c.maxBlocks, i, l.BlockNumber)
+				break
+			}
+		}
+		// the accepted ranges cover the query without overlapping
+		covered := uint64(0)
+		for _, q := range *queried {
+			if q[1]-q[0]+1 <= c.maxBlocks {
+				covered += q[1] - q[0] + 1
+			}
+		}
+		if covered != c.to-c.from+1 {
+			t.Errorf("%d-%d by %d: accepted ranges cover %d blocks", c.from, c.to, c.maxBlocks, covered)
+		}
+	}
+}
+
+func TestFilterLogsKeepsOtherErrors(t *testing.T) {
+	n := newFakeNode("node", 1000)
+	calls := 0
+	n.logs = func(q ethereum.FilterQuery) ([]types.Log, error) {
+		calls += 1
+		return nil, rpcError("invalid topic")
+	}
+	r := newFakeReader(n)
+	_, err := r.FilterLogs(ethereum.FilterQuery{FromBlock: big.NewInt(0), ToBlock: big.NewInt(100)})
+	if err == nil || calls != 1 {
+		t.Errorf("got %v after %d calls, want the error of the only call", err, calls)
+	}
+}
+
+func TestFilterLogsMergesNodes(t *testing.T) {
+	// block 12 has a canonical version and an orphan one
+	headers := map[int64]*types.Header{}
+	for _, number := range []int64{10, 11, 12} {
+		headers[number] = &types.Header{Number: big.NewInt(number), Difficulty: big.NewInt(1)}
+	}
+	orphan := &types.Header{Number: big.NewInt(12), Difficulty: big.NewInt(2)}
+	log := func(header *types.Header, index uint) types.Log {
+		return types.Log{BlockNumber: header.Number.Uint64(), BlockHash: header.Hash(), Index: index}
+	}
+	type logID struct {
+		Block uint64
+		Hash  common.Hash
+		Index uint
+	}
+	cases := []struct {
+		name    string
+		answers [][]types.Log
+		want    []logID
+	}{
+		{
+			"same logs",
+			[][]types.Log{
+				{log(headers[10], 0), log(headers[11], 3)},
+				{log(headers[10], 0), log(headers[11], 3)},
+			},
+			[]logID{{10, headers[10].Hash(), 0}, {11, headers[11].Hash(), 3}},
+		},
+		{
+			"union of the nodes, sorted",
+			[][]types.Log{
+				{log(headers[11], 3), log(headers[10], 0)},
+				{log(headers[10], 0), log(headers[10], 1), log(headers[12], 0)},
+			},
+			[]logID{{10, headers[10].Hash(), 0}, {10, headers[10].Hash(), 1}, {11, headers[11].Hash(), 3}, {12, headers[12].Hash(), 0}},
+		},
+		{
+			"orphan block dropped",
+			[][]types.Log{
+				{log(headers[10], 0), log(headers[12], 0), log(headers[12], 1)},
+				{log(headers[10], 0), log(orphan, 0)},
+			},
+			[]logID{{10, headers[10].Hash(), 0}, {12, headers[12].Hash(), 0}, {12, headers[12].Hash(), 1}},
+		},
+		{
+			"orphan block only node",
+			[][]types.Log{
+				{log(headers[10], 0)},
+				{log(headers[10], 0), log(orphan, 5)},
+			},
+			[]logID{{10, headers[10].Hash(), 0}},
+		},
+	}
+	for _, c := range cases {
+		nodes := []ContextEthereumNode{}
+		for i, answer := range c.answers {
+			n := newFakeNode(fmt.Sprintf("node%d", i), 100)
+			logs := answer
+			n.logs = func(q ethereum.FilterQuery) ([]types.Log, error) { return logs, nil }
+			n.header = func(number int64) (*types.Header, error) { return headers[number], nil }
+			nodes = append(nodes, n)
+		}
+		r := newFakeReader(nodes...)
+		logs, err := r.FilterLogs(ethereum.FilterQuery{FromBlock: big.NewInt(10), ToBlock: big.NewInt(12)})
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		got := []logID{}
+		for _, l := range logs {
+			got = append(got, logID{l.BlockNumber, l.BlockHash, l.Index})
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestTopicsFromHex(t *testing.T) {
+	transfer := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	approval := "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+	to := "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"
+	topics, err := TopicsFromHex([]string{transfer, approval}, []string{}, []string{to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]common.Hash{
+		{common.HexToHash(transfer), common.HexToHash(approval)},
+		{},
+		{common.HexToHash("0x000000000000000000000000cd2a3d9f938e13cd947ec05abc7fe734df8dd826")},
+	}
+	if !reflect.DeepEqual(topics, want) {
+		t.Errorf("TopicsFromHex = %v, want %v", topics, want)
+	}
+
+	for _, invalid := range []string{"0x1234", "transfer", "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD827"} {
+		if _, err := TopicsFromHex([]string{invalid}); err == nil {
+			t.Errorf("TopicsFromHex accepted %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// DEFAULT_MAX_NODE_LAG is the number of blocks a node can be behind
+	// the highest head seen among the nodes before it is skipped
+	DEFAULT_MAX_NODE_LAG uint64 = 10
+	// DEFAULT_FAILURE_THRESHOLD is the number of consecutive failures
+	// that trips the circuit breaker of a node
+	DEFAULT_FAILURE_THRESHOLD int = 5
+	// DEFAULT_RETRY_AFTER is how long a tripped node is skipped before it
+	// is probed again
+	DEFAULT_RETRY_AFTER time.Duration = 30 * time.Second
+)
+
+// weight of the latest sample in the moving averages of latency and
+// error rate
+const healthEWMAWeight float64 = 0.2
+
+// HealthConfig tunes how EthReader decides which nodes to query
+type HealthConfig struct {
+	// MaxLag is the number of blocks a node can lag behind the highest
+	// known head. 0 disables lag detection.
+	MaxLag uint64
+	// FailureThreshold is the number of consecutive failures tripping
+	// the circuit breaker. 0 disables the circuit breaker.
+	FailureThreshold int
+	// RetryAfter is how long a tripped node is skipped before it is
+	// queried again. One more failure trips it again.
+	RetryAfter time.Duration
+}
+
+func DefaultHealthConfig() HealthConfig {
+	return HealthConfig{
+		MaxLag:           DEFAULT_MAX_NODE_LAG,
+		FailureThreshold: DEFAULT_FAILURE_THRESHOLD,
+		RetryAfter:       DEFAULT_RETRY_AFTER,
+	}
+}
+
+// NodeHealth is a snapshot of the health of one node
+type NodeHealth struct {
+	Name                string
+	Successes           uint64
+	Failures            uint64
+	ConsecutiveFailures int
+	// AvgLatency and ErrorRate are exponential moving averages
+	AvgLatency  time.Duration
+	ErrorRate   float64
+	LastError   string
+	HeadBlock   uint64
+	Lag         uint64
+	Lagging     bool
+	CircuitOpen bool
+	// RetryAt is when a tripped node is probed again
+	RetryAt time.Time
+}
+
+// Active returns true if the node is queried by EthReader
+func (self NodeHealth) Active() bool {
+	return !self.Lagging && !self.CircuitOpen
+}
+
+type nodeStats struct {
+	successes           uint64
+	failures            uint64
+	consecutiveFailures int
+	avgLatency          float64
+	errorRate           float64
+	lastError           string
+	head                uint64
+	openedAt            time.Time
+	circuitOpen         bool
+}
+
+type healthTracker struct {
+	mu     sync.Mutex
+	config HealthConfig
+	stats  map[string]*nodeStats
+}
+
+func newHealthTracker() *healthTracker {
+	return &healthTracker{
+		config: DefaultHealthConfig(),
+		stats:  map[string]*nodeStats{},
+	}
+}
+
+func (self *healthTracker) get(name string) *nodeStats {
+	s, found := self.stats[name]
+	if !found {
+		s = &nodeStats{}
+		self.stats[name] = s
+	}
+	return s
+}
+
+// isNodeFailure tells errors caused by the node being unreachable or
+// unhealthy from errors where the node answered properly, such as a
+// reverted call or a tx that is not found, or where the caller cancelled
+// the request
+func isNodeFailure(err error) bool {
+	if err == nil || errors.Is(err, ethereum.NotFound) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+func (self *healthTracker) record(name string, latency time.Duration, err error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	s := self.get(name)
+	if s.avgLatency == 0 {
+		s.avgLatency = float64(latency)
+	} else {
+		s.avgLatency += healthEWMAWeight * (float64(latency) - s.avgLatency)
+	}
+	if !isNodeFailure(err) {
+		s.successes += 1
+		s.consecutiveFailures = 0
+		s.errorRate -= healthEWMAWeight * s.errorRate
+		s.circuitOpen = false
+		return
+	}
+	s.failures += 1
+	s.consecutiveFailures += 1
+	s.errorRate += healthEWMAWeight * (1 - s.errorRate)
+	s.lastError = err.Error()
+	threshold := self.config.FailureThreshold
+	if threshold > 0 && s.consecutiveFailures >= threshold {
+		// a failed probe of a tripped node re-opens the circuit
+		s.circuitOpen = true
+		s.openedAt = time.Now()
+	}
+}
+
+func (self *healthTracker) recordHead(name string, head uint64) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	s := self.get(name)
+	if head > s.head {
+		s.head = head
+	}
+}
+
+func (self *healthTracker) maxHead() uint64 {
+	result := uint64(0)
+	for _, s := range self.stats {
+		if s.head > result {
+			result = s.head
+		}
+	}
+	return result
+}
+
+func (self *healthTracker) snapshot(name string, maxHead uint64, now time.Time) NodeHealth {
+	s := self.get(name)
+	result := NodeHealth{
+		Name:                name,
+		Successes:           s.successes,
+		Failures:            s.failures,
+		ConsecutiveFailures: s.consecutiveFailures,
+		AvgLatency:          time.Duration(s.avgLatency),
+		ErrorRate:           s.errorRate,
+		LastError:           s.lastError,
+		HeadBlock:           s.head,
+	}
+	if s.head > 0 && maxHead > s.head {
+		result.Lag = maxHead - s.head
+	}
+	result.Lagging = self.config.MaxLag > 0 && result.Lag > self.config.MaxLag
+	if s.circuitOpen {
+		result.RetryAt = s.openedAt.Add(self.config.RetryAfter)
+		// once RetryAt has passed the circuit is half open and the node
+		// gets queried again as a probe
+		result.CircuitOpen = now.Before(result.RetryAt)
+	}
+	return result
+}
+
+// SetHealthConfig changes the lag threshold and circuit breaker settings
+func (self *EthReader) SetHealthConfig(config HealthConfig) {
+	self.health.mu.Lock()
+	defer self.health.mu.Unlock()
+	self.health.config = config
+}
+
+// NodeHealth returns the health of every node sorted by name
+func (self *EthReader) NodeHealth() []NodeHealth {
+	self.health.mu.Lock()
+	defer self.health.mu.Unlock()
+	maxHead := self.health.maxHead()
+	now := time.Now()
+	result := []NodeHealth{}
+	for name, _ := range self.nodes {
+		result = append(result, self.health.snapshot(name, maxHead, now))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// activeNodes returns the nodes that are neither lagging nor tripped. If
+// no node is active, every node is returned so reads are still tried.
+func (self *EthReader) activeNodes() []ContextEthereumNode {
+	self.health.mu.Lock()
+	defer self.health.mu.Unlock()
+	maxHead := self.health.maxHead()
+	now := time.Now()
+	result := []ContextEthereumNode{}
+	all := []ContextEthereumNode{}
+	for name, n := range self.nodes {
+		all = append(all, n)
+		if self.health.snapshot(name, maxHead, now).Active() {
+			result = append(result, n)
+		}
+	}
+	if len(result) == 0 {
+		return all
+	}
+	return result
+}
+
+func (self *EthReader) recordNodeResult(n ContextEthereumNode, start time.Time, err error) {
+	self.health.record(n.NodeName(), time.Since(start), err)
+}
+
+// CheckNodesHealth asks every node, including lagging and tripped ones,
+// for its head block and updates the health state with the answers
+func (self *EthReader) CheckNodesHealth() {
+	self.CheckNodesHealthContext(context.Background())
+}
+
+func (self *EthReader) CheckNodesHealthContext(ctx context.Context) {
+	wg := sync.WaitGroup{}
+	for i, _ := range self.nodes {
+		n := self.nodes[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			block, err := n.CurrentBlockContext(ctx)
+			self.recordNodeResult(n, start, err)
+			if err == nil {
+				self.health.recordHead(n.NodeName(), block)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// StartHealthChecks runs CheckNodesHealth every interval in the
+// background until the returned stop function is called
+func (self *EthReader) StartHealthChecks(interval time.Duration) (stop func()) {
+	quit := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			self.CheckNodesHealth()
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	once := sync.Once{}
+	return func() {
+		once.Do(func() { close(quit) })
+	}
+}
@@ -0,0 +1,142 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+func TestIsNodeFailure(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{ethereum.NotFound, false},
+		{context.Canceled, false},
+		{fmt.Errorf("reading: %w", context.Canceled), false},
+		{rpcError("execution reverted"), false},
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("connection refused"), true},
+	}
+	for _, c := range cases {
+		if got := isNodeFailure(c.err); got != c.want {
+			t.Errorf("isNodeFailure(%v) = %t, want %t", c.err, got, c.want)
+		}
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	down := fmt.Errorf("connection refused")
+	config := HealthConfig{FailureThreshold: 3, RetryAfter: time.Minute}
+	cases := []struct {
+		name    string
+		config  HealthConfig
+		results []error
+		// elapsed is the time since the last result
+		elapsed     time.Duration
+		open        bool
+		consecutive int
+	}{
+		{"below threshold", config, []error{down, down}, 0, false, 2},
+		{"tripped", config, []error{down, down, down}, 0, true, 3},
+		{"still tripped before retry", config, []error{down, down, down}, 59 * time.Second, true, 3},
+		{"half open after retry", config, []error{down, down, down}, time.Minute, false, 3},
+		{"successful probe closes", config, []error{down, down, down, nil}, 0, false, 0},
+		{"failed probe trips again", config, []error{down, down, down, down}, 59 * time.Second, true, 4},
+		{"success resets the count", config, []error{down, down, nil, down, down}, 0, false, 2},
+		{"answers are not failures", config, []error{rpcError("execution reverted"), ethereum.NotFound, context.Canceled}, 0, false, 0},
+		{"disabled", HealthConfig{RetryAfter: time.Minute}, []error{down, down, down, down}, 0, false, 4},
+	}
+	for _, c := range cases {
+		tracker := newHealthTracker()
+		tracker.config = c.config
+		for _, err := range c.results {
+			tracker.record("node", time.Millisecond, err)
+		}
+		// openedAt is set from the wall clock on the last failure
+		now := time.Now().Add(c.elapsed)
+		got := tracker.snapshot("node", 0, now)
+		if got.CircuitOpen != c.open || got.ConsecutiveFailures != c.consecutive {
+			t.Errorf("%s: got open %t after %d failures, want %t after %d",
+				c.name, got.CircuitOpen, got.ConsecutiveFailures, c.open, c.consecutive)
+		}
+		if got.Active() == c.open {
+			t.Errorf("%s: Active() = %t with the circuit open %t", c.name, got.Active(), c.open)
+		}
+	}
+}
+
+func TestLagDetection(t *testing.T) {
+	cases := []struct {
+		name    string
+		maxLag  uint64
+		heads   map[string]uint64
+		lagging []string
+	}{
+		{"in sync", 10, map[string]uint64{"a": 100, "b": 100}, []string{}},
+		{"within max lag", 10, map[string]uint64{"a": 100, "b": 90}, []string{}},
+		{"beyond max lag", 10, map[string]uint64{"a": 100, "b": 89, "c": 95}, []string{"b"}},
+		{"unknown head", 10, map[string]uint64{"a": 100, "b": 0}, []string{}},
+		{"disabled", 0, map[string]uint64{"a": 100, "b": 1}, []string{}},
+	}
+	for _, c := range cases {
+		tracker := newHealthTracker()
+		tracker.config.MaxLag = c.maxLag
+		for name, head := range c.heads {
+			tracker.recordHead(name, head)
+		}
+		lagging := []string{}
+		for name := range c.heads {
+			if tracker.snapshot(name, tracker.maxHead(), time.Now()).Lagging {
+				lagging = append(lagging, name)
+			}
+		}
+		sort.Strings(lagging)
+		if fmt.Sprint(lagging) != fmt.Sprint(c.lagging) {
+			t.Errorf("%s: lagging %v, want %v", c.name, lagging, c.lagging)
+		}
+	}
+}
+
+func TestActiveNodes(t *testing.T) {
+	down := fmt.Errorf("connection refused")
+	cases := []struct {
+		name   string
+		heads  map[string]uint64
+		failed []string
+		active []string
+	}{
+		{"all healthy", map[string]uint64{"a": 100, "b": 100, "c": 100}, []string{}, []string{"a", "b", "c"}},
+		{"tripped skipped", map[string]uint64{"a": 100, "b": 100, "c": 100}, []string{"b"}, []string{"a", "c"}},
+		{"lagging skipped", map[string]uint64{"a": 100, "b": 50, "c": 100}, []string{}, []string{"a", "c"}},
+		{"lagging and tripped skipped", map[string]uint64{"a": 100, "b": 50, "c": 100}, []string{"c"}, []string{"a"}},
+		{"all down falls back to all", map[string]uint64{"a": 100, "b": 50, "c": 100}, []string{"a", "c"}, []string{"a", "b", "c"}},
+	}
+	for _, c := range cases {
+		nodes := []ContextEthereumNode{}
+		for name := range c.heads {
+			nodes = append(nodes, newFakeNode(name, c.heads[name]))
+		}
+		r := newFakeReader(nodes...)
+		r.SetHealthConfig(HealthConfig{MaxLag: 10, FailureThreshold: 1, RetryAfter: time.Minute})
+		for name, head := range c.heads {
+			r.health.recordHead(name, head)
+		}
+		for _, name := range c.failed {
+			r.health.record(name, time.Millisecond, down)
+		}
+		active := []string{}
+		for _, n := range r.activeNodes() {
+			active = append(active, n.NodeName())
+		}
+		sort.Strings(active)
+		if fmt.Sprint(active) != fmt.Sprint(c.active) {
+			t.Errorf("%s: active %v, want %v", c.name, active, c.active)
+		}
+	}
+}
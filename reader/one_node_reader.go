@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -18,24 +19,64 @@ import (
 
 const TIMEOUT time.Duration = 4 * time.Second
 
+// tracing big txs takes much longer than a normal call
+const TRACE_TIMEOUT time.Duration = 30 * time.Second
+
+// DEFAULT_MAX_BATCH_SIZE is the number of requests sent in one JSON-RPC
+// batch by default, many providers reject larger batches
+const DEFAULT_MAX_BATCH_SIZE int = 100
+
+// withTimeout bounds ctx by timeout unless the caller already gave it a
+// deadline
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 type OneNodeReader struct {
-	nodeName  string
-	nodeURL   string
-	client    *rpc.Client
-	ethClient *ethclient.Client
-	mu        sync.Mutex
+	nodeName     string
+	nodeURL      string
+	client       *rpc.Client
+	ethClient    *ethclient.Client
+	mu           sync.Mutex
+	traceCapable bool
+	maxBatchSize int
 }
 
 func NewOneNodeReader(name, url string) *OneNodeReader {
 	return &OneNodeReader{
-		nodeName:  name,
-		nodeURL:   url,
-		client:    nil,
-		ethClient: nil,
-		mu:        sync.Mutex{},
+		nodeName:     name,
+		nodeURL:      url,
+		client:       nil,
+		ethClient:    nil,
+		mu:           sync.Mutex{},
+		traceCapable: false,
+		maxBatchSize: DEFAULT_MAX_BATCH_SIZE,
 	}
 }
 
+// SetMaxBatchSize bounds the number of requests sent in one JSON-RPC
+// batch, larger batches are sent in several requests
+func (self *OneNodeReader) SetMaxBatchSize(size int) {
+	self.maxBatchSize = size
+}
+
+// SetTraceCapable marks the node as serving the debug namespace so it
+// is used to trace txs
+func (self *OneNodeReader) SetTraceCapable(capable bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.traceCapable = capable
+}
+
+func (self *OneNodeReader) IsTraceCapable() bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.traceCapable
+}
+
 func (self *OneNodeReader) NodeName() string {
 	return self.nodeName
 }
@@ -49,7 +90,7 @@ func (self *OneNodeReader) initConnection() error {
 	defer self.mu.Unlock()
 	client, err := rpc.Dial(self.NodeURL())
 	if err != nil {
-		return fmt.Errorf("Couldn't connect to %s: %s", self.NodeName, err)
+		return fmt.Errorf("Couldn't connect to %s: %s", self.NodeName(), err)
 	}
 	self.client = client
 	self.ethClient = ethclient.NewClient(self.client)
@@ -73,6 +114,10 @@ func (self *OneNodeReader) EthClient() (*ethclient.Client, error) {
 }
 
 func (self *OneNodeReader) EstimateGas(from, to string, priceGwei float64, value *big.Int, data []byte) (uint64, error) {
+	return self.EstimateGasContext(context.Background(), from, to, priceGwei, value, data)
+}
+
+func (self *OneNodeReader) EstimateGasContext(ctx context.Context, from, to string, priceGwei float64, value *big.Int, data []byte) (uint64, error) {
 	fromAddr := common.HexToAddress(from)
 	var toAddrPtr *common.Address
 	if to != "" {
@@ -84,7 +129,7 @@ func (self *OneNodeReader) EstimateGas(from, to string, priceGwei float64, value
 	if err != nil {
 		return 0, err
 	}
-	timeout, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
 	defer cancel()
 	return ethcli.EstimateGas(timeout, ethereum.CallMsg{
 		From:     fromAddr,
@@ -96,67 +141,176 @@ func (self *OneNodeReader) EstimateGas(from, to string, priceGwei float64, value
 	})
 }
 
-func (self *OneNodeReader) GetCode(address string) (code []byte, err error) {
-	addr := common.HexToAddress(address)
-	ethcli, err := self.EthClient()
+type accessListResult struct {
+	AccessList *types.AccessList `json:"accessList"`
+	Error      string            `json:"error,omitempty"`
+	GasUsed    hexutil.Uint64    `json:"gasUsed"`
+}
+
+// CreateAccessList calls eth_createAccessList to get the access list
+// the tx would touch and the gas it would use with that list attached.
+func (self *OneNodeReader) CreateAccessList(from, to string, priceGwei float64, value *big.Int, data []byte) (types.AccessList, uint64, error) {
+	return self.CreateAccessListContext(context.Background(), from, to, priceGwei, value, data)
+}
+
+// callArg is the call object of eth_estimateGas and eth_createAccessList
+func callArg(from, to string, priceGwei float64, value *big.Int, data []byte) map[string]interface{} {
+	arg := map[string]interface{}{
+		"from":     common.HexToAddress(from),
+		"gasPrice": (*hexutil.Big)(eu.FloatToBigInt(priceGwei, 9)),
+	}
+	if to != "" {
+		arg["to"] = common.HexToAddress(to)
+	}
+	if len(data) > 0 {
+		arg["data"] = hexutil.Bytes(data)
+	}
+	if value != nil {
+		arg["value"] = (*hexutil.Big)(value)
+	}
+	return arg
+}
+
+// EstimateGasWithAccessList estimates the gas of the call with
+// accessList attached, as in an access list or dynamic fee tx
+func (self *OneNodeReader) EstimateGasWithAccessList(from, to string, priceGwei float64, value *big.Int, data []byte, accessList types.AccessList) (uint64, error) {
+	return self.EstimateGasWithAccessListContext(context.Background(), from, to, priceGwei, value, data, accessList)
+}
+
+func (self *OneNodeReader) EstimateGasWithAccessListContext(ctx context.Context, from, to string, priceGwei float64, value *big.Int, data []byte, accessList types.AccessList) (uint64, error) {
+	arg := callArg(from, to, priceGwei, value, data)
+	arg["accessList"] = accessList
+	cli, err := self.Client()
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
+	defer cancel()
+	var gas hexutil.Uint64
+	if err := cli.CallContext(timeout, &gas, "eth_estimateGas", arg); err != nil {
+		return 0, err
+	}
+	return uint64(gas), nil
+}
+
+func (self *OneNodeReader) CreateAccessListContext(ctx context.Context, from, to string, priceGwei float64, value *big.Int, data []byte) (types.AccessList, uint64, error) {
+	arg := callArg(from, to, priceGwei, value, data)
+	cli, err := self.Client()
+	if err != nil {
+		return nil, 0, err
 	}
-	timeout, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
 	defer cancel()
-	return ethcli.CodeAt(timeout, addr, nil)
+	result := accessListResult{}
+	err = cli.CallContext(timeout, &result, "eth_createAccessList", arg)
+	if err != nil {
+		return nil, 0, err
+	}
+	if result.Error != "" {
+		return nil, 0, fmt.Errorf("creating access list failed: %s", result.Error)
+	}
+	if result.AccessList == nil {
+		return types.AccessList{}, uint64(result.GasUsed), nil
+	}
+	return *result.AccessList, uint64(result.GasUsed), nil
+}
+
+func (self *OneNodeReader) GetCode(address string) (code []byte, err error) {
+	return self.GetCodeContext(context.Background(), address)
+}
+
+func (self *OneNodeReader) GetCodeContext(ctx context.Context, address string) (code []byte, err error) {
+	return self.GetCodeAtContext(ctx, address, LatestBlock())
 }
 
 func (self *OneNodeReader) GetGasPriceSuggestion() (*big.Int, error) {
+	return self.GetGasPriceSuggestionContext(context.Background())
+}
+
+func (self *OneNodeReader) GetGasPriceSuggestionContext(ctx context.Context) (*big.Int, error) {
 	ethcli, err := self.EthClient()
 	if err != nil {
 		return nil, err
 	}
-	timeout, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
 	defer cancel()
 	return ethcli.SuggestGasPrice(timeout)
 }
 
-func (self *OneNodeReader) GetBalance(address string) (balance *big.Int, err error) {
+func (self *OneNodeReader) GetGasTipCapSuggestion() (*big.Int, error) {
+	return self.GetGasTipCapSuggestionContext(context.Background())
+}
+
+func (self *OneNodeReader) GetGasTipCapSuggestionContext(ctx context.Context) (*big.Int, error) {
 	ethcli, err := self.EthClient()
 	if err != nil {
 		return nil, err
 	}
-	acc := common.HexToAddress(address)
-	timeout, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
 	defer cancel()
-	return ethcli.BalanceAt(timeout, acc, nil)
+	return ethcli.SuggestGasTipCap(timeout)
 }
 
-func (self *OneNodeReader) GetMinedNonce(address string) (nonce uint64, err error) {
+func (self *OneNodeReader) ChainID() (int64, error) {
+	return self.ChainIDContext(context.Background())
+}
+
+func (self *OneNodeReader) ChainIDContext(ctx context.Context) (int64, error) {
 	ethcli, err := self.EthClient()
 	if err != nil {
 		return 0, err
 	}
-	acc := common.HexToAddress(address)
-	timeout, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
 	defer cancel()
-	return ethcli.NonceAt(timeout, acc, nil)
+	chainID, err := ethcli.ChainID(timeout)
+	if err != nil {
+		return 0, err
+	}
+	return chainID.Int64(), nil
+}
+
+func (self *OneNodeReader) GetBalance(address string) (balance *big.Int, err error) {
+	return self.GetBalanceContext(context.Background(), address)
+}
+
+func (self *OneNodeReader) GetBalanceContext(ctx context.Context, address string) (balance *big.Int, err error) {
+	return self.GetBalanceAtContext(ctx, address, LatestBlock())
+}
+
+func (self *OneNodeReader) GetMinedNonce(address string) (nonce uint64, err error) {
+	return self.GetMinedNonceContext(context.Background(), address)
+}
+
+func (self *OneNodeReader) GetMinedNonceContext(ctx context.Context, address string) (nonce uint64, err error) {
+	return self.GetNonceAtContext(ctx, address, LatestBlock())
 }
 
 func (self *OneNodeReader) GetPendingNonce(address string) (nonce uint64, err error) {
+	return self.GetPendingNonceContext(context.Background(), address)
+}
+
+func (self *OneNodeReader) GetPendingNonceContext(ctx context.Context, address string) (nonce uint64, err error) {
 	ethcli, err := self.EthClient()
 	if err != nil {
 		return 0, err
 	}
 	acc := common.HexToAddress(address)
-	timeout, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
 	defer cancel()
 	return ethcli.PendingNonceAt(timeout, acc)
 }
 
 func (self *OneNodeReader) TransactionReceipt(txHash string) (receipt *types.Receipt, err error) {
+	return self.TransactionReceiptContext(context.Background(), txHash)
+}
+
+func (self *OneNodeReader) TransactionReceiptContext(ctx context.Context, txHash string) (receipt *types.Receipt, err error) {
 	ethcli, err := self.EthClient()
 	if err != nil {
 		return nil, err
 	}
 	hash := common.HexToHash(txHash)
-	timeout, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
 	defer cancel()
 	return ethcli.TransactionReceipt(timeout, hash)
 }
@@ -179,13 +333,17 @@ func (self *OneNodeReader) transactionByHashOnNode(ctx context.Context, hash com
 }
 
 func (self *OneNodeReader) TransactionByHash(txHash string) (tx *eu.Transaction, isPending bool, err error) {
+	return self.TransactionByHashContext(context.Background(), txHash)
+}
+
+func (self *OneNodeReader) TransactionByHashContext(ctx context.Context, txHash string) (tx *eu.Transaction, isPending bool, err error) {
 	cli, err := self.Client()
 	if err != nil {
 		return nil, false, err
 	}
 
 	hash := common.HexToHash(txHash)
-	timeout, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
 	defer cancel()
 	return self.transactionByHashOnNode(timeout, hash, cli)
 }
@@ -201,6 +359,10 @@ func (self *OneNodeReader) TransactionByHash(txHash string) (tx *eu.Transaction,
 // }
 
 func (self *OneNodeReader) HeaderByNumber(number int64) (*types.Header, error) {
+	return self.HeaderByNumberContext(context.Background(), number)
+}
+
+func (self *OneNodeReader) HeaderByNumberContext(ctx context.Context, number int64) (*types.Header, error) {
 	ethcli, err := self.EthClient()
 	if err != nil {
 		return nil, err
@@ -209,69 +371,55 @@ func (self *OneNodeReader) HeaderByNumber(number int64) (*types.Header, error) {
 	if number > -1 {
 		numberBig = big.NewInt(number)
 	}
-	timeout, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
 	defer cancel()
 	return ethcli.HeaderByNumber(timeout, numberBig)
 }
 
 func (self *OneNodeReader) GetLogs(fromBlock, toBlock int, addresses []string, topic string) ([]types.Log, error) {
-	ethcli, err := self.EthClient()
-	if err != nil {
-		return nil, err
-	}
+	return self.GetLogsContext(context.Background(), fromBlock, toBlock, addresses, topic)
+}
 
-	q := &ethereum.FilterQuery{}
-	q.BlockHash = nil
-	q.FromBlock = big.NewInt(int64(fromBlock))
-	if toBlock < 0 {
-		q.ToBlock = nil
-	} else {
-		q.ToBlock = big.NewInt(int64(toBlock))
-	}
-	q.Addresses = eu.HexToAddresses(addresses)
-	q.Topics = [][]common.Hash{
-		[]common.Hash{eu.HexToHash(topic)},
-	}
+func (self *OneNodeReader) GetLogsContext(ctx context.Context, fromBlock, toBlock int, addresses []string, topic string) ([]types.Log, error) {
+	return self.FilterLogsContext(ctx, logsQuery(fromBlock, toBlock, addresses, topic))
+}
 
-	timeout, cancel := context.WithTimeout(context.Background(), TIMEOUT)
-	defer cancel()
-	return ethcli.FilterLogs(timeout, *q)
+func (self *OneNodeReader) FilterLogs(q ethereum.FilterQuery) ([]types.Log, error) {
+	return self.FilterLogsContext(context.Background(), q)
 }
 
-func (self *OneNodeReader) ReadContractToBytes(atBlock int64, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) ([]byte, error) {
+func (self *OneNodeReader) FilterLogsContext(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
 	ethcli, err := self.EthClient()
 	if err != nil {
 		return nil, err
 	}
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
+	defer cancel()
+	return ethcli.FilterLogs(timeout, q)
+}
+
+func (self *OneNodeReader) ReadContractToBytes(atBlock int64, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) ([]byte, error) {
+	return self.ReadContractToBytesContext(context.Background(), atBlock, from, caddr, abi, method, args...)
+}
 
-	contract := eu.HexToAddress(caddr)
+func (self *OneNodeReader) ReadContractToBytesContext(ctx context.Context, atBlock int64, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) ([]byte, error) {
 	data, err := abi.Pack(method, args...)
 	if err != nil {
 		return nil, err
 	}
-
-	var blockBig *big.Int
-	if atBlock > 0 {
-		blockBig = big.NewInt(atBlock)
-	}
-	timeout, cancel := context.WithTimeout(context.Background(), 4*time.Second)
-	defer cancel()
-	return ethcli.CallContract(timeout, ethereum.CallMsg{
-		From:     eu.HexToAddress(from),
-		To:       &contract,
-		Gas:      0,
-		GasPrice: nil,
-		Value:    nil,
-		Data:     data,
-	}, blockBig)
+	return self.CallContractAtContext(ctx, BlockRefFromInt64(atBlock), from, caddr, data)
 }
 
 func (self *OneNodeReader) CurrentBlock() (uint64, error) {
+	return self.CurrentBlockContext(context.Background())
+}
+
+func (self *OneNodeReader) CurrentBlockContext(ctx context.Context) (uint64, error) {
 	ethcli, err := self.EthClient()
 	if err != nil {
 		return 0, err
 	}
-	timeout, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
 	defer cancel()
 	header, err := ethcli.HeaderByNumber(timeout, nil)
 	if err != nil {
@@ -279,3 +427,290 @@ func (self *OneNodeReader) CurrentBlock() (uint64, error) {
 	}
 	return header.Number.Uint64(), nil
 }
+
+// TraceTransaction returns the call tree of a mined tx using
+// debug_traceTransaction with the built-in callTracer
+func (self *OneNodeReader) TraceTransaction(txHash string) (*CallFrame, error) {
+	return self.TraceTransactionContext(context.Background(), txHash)
+}
+
+func (self *OneNodeReader) TraceTransactionContext(ctx context.Context, txHash string) (*CallFrame, error) {
+	if !self.IsTraceCapable() {
+		return nil, fmt.Errorf("%s is not trace capable", self.NodeName())
+	}
+	cli, err := self.Client()
+	if err != nil {
+		return nil, err
+	}
+	timeout, cancel := withTimeout(ctx, TRACE_TIMEOUT)
+	defer cancel()
+	var result *CallFrame
+	err = cli.CallContext(
+		timeout, &result, "debug_traceTransaction",
+		common.HexToHash(txHash),
+		map[string]interface{}{"tracer": "callTracer"},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ethereum.NotFound
+	}
+	return result, nil
+}
+
+func (self *OneNodeReader) callRPC(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	cli, err := self.Client()
+	if err != nil {
+		return err
+	}
+	timeout, cancel := withTimeout(ctx, TIMEOUT)
+	defer cancel()
+	return cli.CallContext(timeout, result, method, args...)
+}
+
+func (self *OneNodeReader) GetBalanceAt(address string, block BlockRef) (*big.Int, error) {
+	return self.GetBalanceAtContext(context.Background(), address, block)
+}
+
+func (self *OneNodeReader) GetBalanceAtContext(ctx context.Context, address string, block BlockRef) (*big.Int, error) {
+	var result hexutil.Big
+	err := self.callRPC(ctx, &result, "eth_getBalance", common.HexToAddress(address), block)
+	if err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+func (self *OneNodeReader) GetCodeAt(address string, block BlockRef) ([]byte, error) {
+	return self.GetCodeAtContext(context.Background(), address, block)
+}
+
+func (self *OneNodeReader) GetCodeAtContext(ctx context.Context, address string, block BlockRef) ([]byte, error) {
+	var result hexutil.Bytes
+	err := self.callRPC(ctx, &result, "eth_getCode", common.HexToAddress(address), block)
+	return result, err
+}
+
+func (self *OneNodeReader) GetNonceAt(address string, block BlockRef) (uint64, error) {
+	return self.GetNonceAtContext(context.Background(), address, block)
+}
+
+func (self *OneNodeReader) GetNonceAtContext(ctx context.Context, address string, block BlockRef) (uint64, error) {
+	var result hexutil.Uint64
+	err := self.callRPC(ctx, &result, "eth_getTransactionCount", common.HexToAddress(address), block)
+	return uint64(result), err
+}
+
+func (self *OneNodeReader) GetStorageAt(address string, slot common.Hash, block BlockRef) (common.Hash, error) {
+	return self.GetStorageAtContext(context.Background(), address, slot, block)
+}
+
+func (self *OneNodeReader) GetStorageAtContext(ctx context.Context, address string, slot common.Hash, block BlockRef) (common.Hash, error) {
+	var result hexutil.Bytes
+	err := self.callRPC(ctx, &result, "eth_getStorageAt", common.HexToAddress(address), slot, block)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(result), nil
+}
+
+func (self *OneNodeReader) CallContractAt(block BlockRef, from string, caddr string, data []byte) ([]byte, error) {
+	return self.CallContractAtContext(context.Background(), block, from, caddr, data)
+}
+
+func (self *OneNodeReader) CallContractAtContext(ctx context.Context, block BlockRef, from string, caddr string, data []byte) ([]byte, error) {
+	arg := map[string]interface{}{
+		"from": common.HexToAddress(from),
+		"to":   common.HexToAddress(caddr),
+	}
+	if len(data) > 0 {
+		arg["data"] = hexutil.Bytes(data)
+	}
+	var result hexutil.Bytes
+	err := self.callRPC(ctx, &result, "eth_call", arg, block)
+	return result, err
+}
+
+// batchCallRPC sends elems in batches of at most maxBatchSize requests.
+// The errors of the elements are in their Error field, the returned
+// error means a batch as a whole failed.
+func (self *OneNodeReader) batchCallRPC(ctx context.Context, elems []rpc.BatchElem) error {
+	cli, err := self.Client()
+	if err != nil {
+		return err
+	}
+	size := self.maxBatchSize
+	if size <= 0 {
+		size = len(elems)
+	}
+	for start := 0; start < len(elems); start += size {
+		end := start + size
+		if end > len(elems) {
+			end = len(elems)
+		}
+		timeout, cancel := withTimeout(ctx, TIMEOUT)
+		err = cli.BatchCallContext(timeout, elems[start:end])
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (self *OneNodeReader) BatchGetBalancesAt(addresses []string, block BlockRef) ([]*big.Int, []error, error) {
+	return self.BatchGetBalancesAtContext(context.Background(), addresses, block)
+}
+
+func (self *OneNodeReader) BatchGetBalancesAtContext(ctx context.Context, addresses []string, block BlockRef) ([]*big.Int, []error, error) {
+	results := make([]hexutil.Big, len(addresses))
+	elems := []rpc.BatchElem{}
+	for i, address := range addresses {
+		elems = append(elems, rpc.BatchElem{
+			Method: "eth_getBalance",
+			Args:   []interface{}{common.HexToAddress(address), block},
+			Result: &results[i],
+		})
+	}
+	if err := self.batchCallRPC(ctx, elems); err != nil {
+		return nil, nil, err
+	}
+	balances := []*big.Int{}
+	errs := []error{}
+	for i, elem := range elems {
+		if elem.Error != nil {
+			balances = append(balances, nil)
+		} else {
+			balances = append(balances, (*big.Int)(&results[i]))
+		}
+		errs = append(errs, elem.Error)
+	}
+	return balances, errs, nil
+}
+
+func (self *OneNodeReader) BatchGetNoncesAt(addresses []string, block BlockRef) ([]uint64, []error, error) {
+	return self.BatchGetNoncesAtContext(context.Background(), addresses, block)
+}
+
+func (self *OneNodeReader) BatchGetNoncesAtContext(ctx context.Context, addresses []string, block BlockRef) ([]uint64, []error, error) {
+	results := make([]hexutil.Uint64, len(addresses))
+	elems := []rpc.BatchElem{}
+	for i, address := range addresses {
+		elems = append(elems, rpc.BatchElem{
+			Method: "eth_getTransactionCount",
+			Args:   []interface{}{common.HexToAddress(address), block},
+			Result: &results[i],
+		})
+	}
+	if err := self.batchCallRPC(ctx, elems); err != nil {
+		return nil, nil, err
+	}
+	nonces := []uint64{}
+	errs := []error{}
+	for i, elem := range elems {
+		nonces = append(nonces, uint64(results[i]))
+		errs = append(errs, elem.Error)
+	}
+	return nonces, errs, nil
+}
+
+func (self *OneNodeReader) BatchTransactionReceipts(txHashes []string) ([]*types.Receipt, []error, error) {
+	return self.BatchTransactionReceiptsContext(context.Background(), txHashes)
+}
+
+// BatchTransactionReceiptsContext returns ethereum.NotFound as the error of
+// the txs that are not mined
+func (self *OneNodeReader) BatchTransactionReceiptsContext(ctx context.Context, txHashes []string) ([]*types.Receipt, []error, error) {
+	receipts := make([]*types.Receipt, len(txHashes))
+	elems := []rpc.BatchElem{}
+	for i, txHash := range txHashes {
+		elems = append(elems, rpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{common.HexToHash(txHash)},
+			Result: &receipts[i],
+		})
+	}
+	if err := self.batchCallRPC(ctx, elems); err != nil {
+		return nil, nil, err
+	}
+	errs := []error{}
+	for i, elem := range elems {
+		err := elem.Error
+		if err == nil && receipts[i] == nil {
+			err = ethereum.NotFound
+		}
+		errs = append(errs, err)
+	}
+	return receipts, errs, nil
+}
+
+func (self *OneNodeReader) BatchTransactionsByHash(txHashes []string) ([]*eu.Transaction, []error, error) {
+	return self.BatchTransactionsByHashContext(context.Background(), txHashes)
+}
+
+// BatchTransactionsByHashContext returns ethereum.NotFound as the error of
+// the txs the node doesn't know. Pending txs have a nil Extra.BlockNumber.
+func (self *OneNodeReader) BatchTransactionsByHashContext(ctx context.Context, txHashes []string) ([]*eu.Transaction, []error, error) {
+	txs := make([]*eu.Transaction, len(txHashes))
+	elems := []rpc.BatchElem{}
+	for i, txHash := range txHashes {
+		elems = append(elems, rpc.BatchElem{
+			Method: "eth_getTransactionByHash",
+			Args:   []interface{}{common.HexToHash(txHash)},
+			Result: &txs[i],
+		})
+	}
+	if err := self.batchCallRPC(ctx, elems); err != nil {
+		return nil, nil, err
+	}
+	errs := []error{}
+	for i, elem := range elems {
+		err := elem.Error
+		if err == nil && txs[i] == nil {
+			err = ethereum.NotFound
+		} else if err == nil {
+			if _, r, _ := txs[i].RawSignatureValues(); r == nil {
+				txs[i] = nil
+				err = fmt.Errorf("server returned transaction without signature")
+			}
+		}
+		errs = append(errs, err)
+	}
+	return txs, errs, nil
+}
+
+func (self *OneNodeReader) BatchHeadersByNumber(numbers []int64) ([]*types.Header, []error, error) {
+	return self.BatchHeadersByNumberContext(context.Background(), numbers)
+}
+
+// BatchHeadersByNumberContext reads the genesis header for 0 and the
+// latest one for negative numbers as HeaderByNumber does. It returns
+// ethereum.NotFound as the error of the blocks the node doesn't have.
+func (self *OneNodeReader) BatchHeadersByNumberContext(ctx context.Context, numbers []int64) ([]*types.Header, []error, error) {
+	headers := make([]*types.Header, len(numbers))
+	elems := []rpc.BatchElem{}
+	for i, number := range numbers {
+		block := LatestBlock()
+		if number > -1 {
+			block = BlockNumber(uint64(number))
+		}
+		elems = append(elems, rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{block, false},
+			Result: &headers[i],
+		})
+	}
+	if err := self.batchCallRPC(ctx, elems); err != nil {
+		return nil, nil, err
+	}
+	errs := []error{}
+	for i, elem := range elems {
+		err := elem.Error
+		if err == nil && headers[i] == nil {
+			err = ethereum.NotFound
+		}
+		errs = append(errs, err)
+	}
+	return headers, errs, nil
+}
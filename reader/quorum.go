@@ -0,0 +1,133 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// NodeAnswer is the answer of one node to a quorum read. Value is the
+// printable form of the returned value, empty if the node failed.
+type NodeAnswer struct {
+	Node  string
+	Value string
+	Error error
+}
+
+// QuorumError is returned by quorum reads when fewer than Required nodes
+// agree on the same value. It lists the answer of every node.
+type QuorumError struct {
+	Required int
+	Block    string
+	Answers  []NodeAnswer
+}
+
+func (self *QuorumError) Error() string {
+	lines := []string{}
+	for i, a := range self.Answers {
+		if a.Error != nil {
+			lines = append(lines, fmt.Sprintf("%d. %s: error: %s", i+1, a.Node, a.Error))
+		} else {
+			lines = append(lines, fmt.Sprintf("%d. %s: %s", i+1, a.Node, a.Value))
+		}
+	}
+	return fmt.Sprintf(
+		"Couldn't get %d agreeing answers at block %s:\n%s",
+		self.Required, self.Block, strings.Join(lines, "\n"),
+	)
+}
+
+// SetQuorum makes the balance, nonce, code, storage and contract call
+// reads require quorum nodes to return the same value instead of
+// returning the first answer. If pinBlock is true, reads at the latest
+// block are pinned to the current block number first so every node
+// answers for the same block. A quorum <= 1 disables consensus reads.
+func (self *EthReader) SetQuorum(quorum int, pinBlock bool) error {
+	if quorum > len(self.nodes) {
+		return fmt.Errorf("quorum %d is bigger than the number of nodes (%d)", quorum, len(self.nodes))
+	}
+	self.quorum = quorum
+	self.pinQuorumBlock = pinBlock
+	return nil
+}
+
+func (self *EthReader) isQuorumEnabled() bool {
+	return self.quorum > 1
+}
+
+func answerKey(value interface{}) string {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return "<nil>"
+		}
+		return v.String()
+	case []byte:
+		return hexutil.Encode(v)
+	case common.Hash:
+		return v.Hex()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+type quorumResponse struct {
+	Node  string
+	Value interface{}
+	Error error
+}
+
+// readWithQuorum runs read on every node and returns the value returned
+// by at least self.quorum of them
+func (self *EthReader) readWithQuorum(ctx context.Context, block BlockRef, read func(n ContextEthereumNode, block BlockRef) (interface{}, error)) (interface{}, error) {
+	if self.pinQuorumBlock && block.IsLatest() {
+		current, err := self.CurrentBlockContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't pin the block for the quorum read: %s", err)
+		}
+		block = BlockNumber(current)
+	}
+	nodes := self.activeNodes()
+	resCh := make(chan quorumResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
+		go func() {
+			var value interface{}
+			err := self.callNode(ctx, n, func() (err error) {
+				value, err = read(n, block)
+				return err
+			})
+			resCh <- quorumResponse{
+				Node:  n.NodeName(),
+				Value: value,
+				Error: err,
+			}
+		}()
+	}
+	answers := []NodeAnswer{}
+	counts := map[string]int{}
+	for i := 0; i < len(nodes); i++ {
+		result := <-resCh
+		if result.Error != nil {
+			answers = append(answers, NodeAnswer{Node: result.Node, Error: result.Error})
+		} else {
+			key := answerKey(result.Value)
+			answers = append(answers, NodeAnswer{Node: result.Node, Value: key})
+			counts[key] += 1
+			if counts[key] >= self.quorum {
+				return result.Value, nil
+			}
+		}
+	}
+	sort.Slice(answers, func(i, j int) bool { return answers[i].Node < answers[j].Node })
+	return nil, &QuorumError{
+		Required: self.quorum,
+		Block:    block.String(),
+		Answers:  answers,
+	}
+}
@@ -0,0 +1,137 @@
+package reader
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// balanceNode answers every balance read with balance, or fails if
+// balance is negative, and records the blocks it was asked for
+func balanceNode(name string, head uint64, balance int64, blocks *sync.Map) *fakeNode {
+	n := newFakeNode(name, head)
+	n.balance = func(address string, block BlockRef) (*big.Int, error) {
+		blocks.Store(name, block.String())
+		if balance < 0 {
+			return nil, fmt.Errorf("connection refused")
+		}
+		return big.NewInt(balance), nil
+	}
+	return n
+}
+
+func TestReadWithQuorum(t *testing.T) {
+	cases := []struct {
+		name     string
+		quorum   int
+		balances []int64
+		want     int64
+		// answers is the QuorumError listing, nil if the read succeeds
+		answers []string
+	}{
+		{"all agree", 3, []int64{5, 5, 5}, 5, nil},
+		{"majority agrees", 2, []int64{5, 7, 5}, 5, nil},
+		{"agreement despite a failure", 2, []int64{5, -1, 5}, 5, nil},
+		{"no quorum", 3, []int64{5, 5, 7}, 0, []string{"node0: 5", "node1: 5", "node2: 7"}},
+		{"all disagree", 2, []int64{5, 6, 7}, 0, []string{"node0: 5", "node1: 6", "node2: 7"}},
+		{"failures", 2, []int64{5, -1, -1}, 0, []string{"node0: 5", "node1: error", "node2: error"}},
+	}
+	for _, c := range cases {
+		nodes := []ContextEthereumNode{}
+		for i, balance := range c.balances {
+			nodes = append(nodes, balanceNode(fmt.Sprintf("node%d", i), 100, balance, &sync.Map{}))
+		}
+		r := newFakeReader(nodes...)
+		if err := r.SetQuorum(c.quorum, false); err != nil {
+			t.Fatal(err)
+		}
+		balance, err := r.GetBalanceAt("0x00000000000000000000000000000000000a11ce", BlockNumber(90))
+		if c.answers == nil {
+			if err != nil || balance.Int64() != c.want {
+				t.Errorf("%s: got %v, %v, want %d", c.name, balance, err, c.want)
+			}
+			continue
+		}
+		quorumErr := &QuorumError{}
+		if !errors.As(err, &quorumErr) {
+			t.Errorf("%s: got %v, %v, want a QuorumError", c.name, balance, err)
+			continue
+		}
+		answers := []string{}
+		for _, a := range quorumErr.Answers {
+			if a.Error != nil {
+				answers = append(answers, a.Node+": error")
+			} else {
+				answers = append(answers, a.Node+": "+a.Value)
+			}
+		}
+		if quorumErr.Required != c.quorum || quorumErr.Block != BlockNumber(90).String() || !reflect.DeepEqual(answers, c.answers) {
+			t.Errorf("%s: got quorum %d at %s with %v, want %d at %s with %v",
+				c.name, quorumErr.Required, quorumErr.Block, answers, c.quorum, BlockNumber(90).String(), c.answers)
+		}
+	}
+}
+
+func TestSetQuorum(t *testing.T) {
+	r := newFakeReader(newFakeNode("a", 1), newFakeNode("b", 1))
+	if err := r.SetQuorum(3, false); err == nil {
+		t.Errorf("quorum bigger than the number of nodes accepted")
+	}
+	if err := r.SetQuorum(2, false); err != nil || !r.isQuorumEnabled() {
+		t.Errorf("quorum of 2 not enabled: %v", err)
+	}
+	if err := r.SetQuorum(1, false); err != nil || r.isQuorumEnabled() {
+		t.Errorf("quorum of 1 enabled: %v", err)
+	}
+}
+
+func TestQuorumPinsBlock(t *testing.T) {
+	heads := []string{BlockNumber(100).String(), BlockNumber(103).String(), BlockNumber(105).String()}
+	cases := []struct {
+		name  string
+		pin   bool
+		block BlockRef
+		// every node reads the same block, one of want
+		want []string
+	}{
+		{"latest pinned to a head", true, LatestBlock(), heads},
+		{"number kept", true, BlockNumber(90), []string{BlockNumber(90).String()}},
+		{"latest without pinning", false, LatestBlock(), []string{LatestBlock().String()}},
+	}
+	for _, c := range cases {
+		blocks := &sync.Map{}
+		r := newFakeReader(
+			balanceNode("a", 100, 5, blocks),
+			balanceNode("b", 105, 5, blocks),
+			balanceNode("c", 103, 5, blocks),
+		)
+		if err := r.SetQuorum(3, c.pin); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.GetBalanceAt("0x00000000000000000000000000000000000a11ce", c.block); err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		read := map[string]bool{}
+		blocks.Range(func(name, block interface{}) bool {
+			read[block.(string)] = true
+			return true
+		})
+		if len(read) != 1 {
+			t.Errorf("%s: nodes read at %v, want one block", c.name, read)
+			continue
+		}
+		for block := range read {
+			found := false
+			for _, want := range c.want {
+				found = found || block == want
+			}
+			if !found {
+				t.Errorf("%s: read at %s, want one of %v", c.name, block, c.want)
+			}
+		}
+	}
+}
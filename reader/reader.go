@@ -1,6 +1,7 @@
 package reader
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	eu "github.com/tranvictor/ethutils"
 	. "github.com/tranvictor/ethutils/explorers"
@@ -26,19 +28,39 @@ const (
 	DEFAULT_TOMOSCAN_APIKEY  string = ""
 )
 
+// EthReader sends every read to its nodes and returns the first answer.
+// Each method talking to the nodes has a Context variant, such as
+// GetBalanceContext, stopping the requests when ctx is done. Requests
+// without a deadline in ctx time out after TIMEOUT.
 type EthReader struct {
-	nodes map[string]EthereumNode
-	be    BlockExplorer
+	nodes       map[string]ContextEthereumNode
+	be          BlockExplorer
+	ensRegistry string
+
+	// see SetQuorum
+	quorum         int
+	pinQuorumBlock bool
+
+	// see SetHealthConfig and NodeHealth
+	health *healthTracker
+
+	// see SetRetryPolicy
+	retryPolicy eu.RetryPolicy
+
+	// see SetCache
+	cache *chainCache
 }
 
 func NewEthReaderGeneric(nodes map[string]string, be BlockExplorer) *EthReader {
-	ns := map[string]EthereumNode{}
+	ns := map[string]ContextEthereumNode{}
 	for name, c := range nodes {
 		ns[name] = NewOneNodeReader(name, c)
 	}
 	return &EthReader{
-		nodes: ns,
-		be:    be,
+		nodes:       ns,
+		be:          be,
+		ensRegistry: eu.ENS_REGISTRY_ADDRESS,
+		health:      newHealthTracker(),
 	}
 }
 
@@ -63,11 +85,24 @@ type estimateGasResult struct {
 }
 
 func (self *EthReader) EstimateExactGas(from, to string, priceGwei float64, value *big.Int, data []byte) (uint64, error) {
-	resCh := make(chan estimateGasResult, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
+	return self.EstimateExactGasContext(context.Background(), from, to, priceGwei, value, data)
+}
+
+func (self *EthReader) EstimateExactGasContext(ctx context.Context, from, to string, priceGwei float64, value *big.Int, data []byte) (uint64, error) {
+	from, to, err := self.resolvePairAt(ctx, LatestBlock(), from, to)
+	if err != nil {
+		return 0, err
+	}
+	nodes := self.activeNodes()
+	resCh := make(chan estimateGasResult, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
 		go func() {
-			gas, err := n.EstimateGas(from, to, priceGwei, value, data)
+			var gas uint64
+			err := self.callNode(ctx, n, func() (err error) {
+				gas, err = n.EstimateGasContext(ctx, from, to, priceGwei, value, data)
+				return err
+			})
 			resCh <- estimateGasResult{
 				Gas:   gas,
 				Error: wrapError(err, n.NodeName()),
@@ -75,7 +110,7 @@ func (self *EthReader) EstimateExactGas(from, to string, priceGwei float64, valu
 		}()
 	}
 	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
+	for i := 0; i < len(nodes); i++ {
 		result := <-resCh
 		if result.Error == nil {
 			return result.Gas, result.Error
@@ -86,7 +121,97 @@ func (self *EthReader) EstimateExactGas(from, to string, priceGwei float64, valu
 }
 
 func (self *EthReader) EstimateGas(from, to string, priceGwei, value float64, data []byte) (uint64, error) {
-	return self.EstimateExactGas(from, to, priceGwei, eu.FloatToBigInt(value, 18), data)
+	return self.EstimateGasContext(context.Background(), from, to, priceGwei, value, data)
+}
+
+func (self *EthReader) EstimateGasContext(ctx context.Context, from, to string, priceGwei, value float64, data []byte) (uint64, error) {
+	return self.EstimateExactGasContext(ctx, from, to, priceGwei, eu.FloatToBigInt(value, 18), data)
+}
+
+// EstimateGasWithAccessList estimates the gas of the call with
+// accessList attached. Unlike the gas used returned by CreateAccessList,
+// which is after refunds, it is a gas limit the call can run with.
+func (self *EthReader) EstimateGasWithAccessList(from, to string, priceGwei float64, value *big.Int, data []byte, accessList types.AccessList) (uint64, error) {
+	return self.EstimateGasWithAccessListContext(context.Background(), from, to, priceGwei, value, data, accessList)
+}
+
+func (self *EthReader) EstimateGasWithAccessListContext(ctx context.Context, from, to string, priceGwei float64, value *big.Int, data []byte, accessList types.AccessList) (uint64, error) {
+	from, to, err := self.resolvePairAt(ctx, LatestBlock(), from, to)
+	if err != nil {
+		return 0, err
+	}
+	nodes := self.activeNodes()
+	resCh := make(chan estimateGasResult, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
+		go func() {
+			var gas uint64
+			err := self.callNode(ctx, n, func() (err error) {
+				gas, err = n.EstimateGasWithAccessListContext(ctx, from, to, priceGwei, value, data, accessList)
+				return err
+			})
+			resCh <- estimateGasResult{
+				Gas:   gas,
+				Error: wrapError(err, n.NodeName()),
+			}
+		}()
+	}
+	errs := []error{}
+	for i := 0; i < len(nodes); i++ {
+		result := <-resCh
+		if result.Error == nil {
+			return result.Gas, result.Error
+		}
+		errs = append(errs, result.Error)
+	}
+	return 0, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
+}
+
+type createAccessListResult struct {
+	AccessList types.AccessList
+	GasUsed    uint64
+	Error      error
+}
+
+// CreateAccessList returns the access list generated by the nodes through
+// eth_createAccessList for the call together with the gas the call uses
+// when the access list is attached.
+func (self *EthReader) CreateAccessList(from, to string, priceGwei float64, value *big.Int, data []byte) (types.AccessList, uint64, error) {
+	return self.CreateAccessListContext(context.Background(), from, to, priceGwei, value, data)
+}
+
+func (self *EthReader) CreateAccessListContext(ctx context.Context, from, to string, priceGwei float64, value *big.Int, data []byte) (types.AccessList, uint64, error) {
+	from, to, err := self.resolvePairAt(ctx, LatestBlock(), from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+	nodes := self.activeNodes()
+	resCh := make(chan createAccessListResult, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
+		go func() {
+			var accessList types.AccessList
+			var gasUsed uint64
+			err := self.callNode(ctx, n, func() (err error) {
+				accessList, gasUsed, err = n.CreateAccessListContext(ctx, from, to, priceGwei, value, data)
+				return err
+			})
+			resCh <- createAccessListResult{
+				AccessList: accessList,
+				GasUsed:    gasUsed,
+				Error:      wrapError(err, n.NodeName()),
+			}
+		}()
+	}
+	errs := []error{}
+	for i := 0; i < len(nodes); i++ {
+		result := <-resCh
+		if result.Error == nil {
+			return result.AccessList, result.GasUsed, result.Error
+		}
+		errs = append(errs, result.Error)
+	}
+	return nil, 0, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
 }
 
 type getCodeResponse struct {
@@ -95,11 +220,50 @@ type getCodeResponse struct {
 }
 
 func (self *EthReader) GetCode(address string) (code []byte, err error) {
-	resCh := make(chan getCodeResponse, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
+	return self.GetCodeContext(context.Background(), address)
+}
+
+func (self *EthReader) GetCodeContext(ctx context.Context, address string) (code []byte, err error) {
+	return self.GetCodeAtContext(ctx, address, LatestBlock())
+}
+
+// GetCodeAt returns the code of the address at the given block
+func (self *EthReader) GetCodeAt(address string, block BlockRef) (code []byte, err error) {
+	return self.GetCodeAtContext(context.Background(), address, block)
+}
+
+func (self *EthReader) GetCodeAtContext(ctx context.Context, address string, block BlockRef) (code []byte, err error) {
+	address, err = self.ResolveAddressAtContext(ctx, block, address)
+	if err != nil {
+		return nil, err
+	}
+	err = self.cached(self.stateCacheKey(ctx, block, "code", address), &code, func() (bool, error) {
+		code, err = self.getCodeAt(ctx, address, block)
+		return true, err
+	})
+	return code, err
+}
+
+func (self *EthReader) getCodeAt(ctx context.Context, address string, block BlockRef) ([]byte, error) {
+	if self.isQuorumEnabled() {
+		value, err := self.readWithQuorum(ctx, block, func(n ContextEthereumNode, block BlockRef) (interface{}, error) {
+			return n.GetCodeAtContext(ctx, address, block)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return value.([]byte), nil
+	}
+	nodes := self.activeNodes()
+	resCh := make(chan getCodeResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
 		go func() {
-			code, err := n.GetCode(address)
+			var code []byte
+			err := self.callNode(ctx, n, func() (err error) {
+				code, err = n.GetCodeAtContext(ctx, address, block)
+				return err
+			})
 			resCh <- getCodeResponse{
 				Code:  code,
 				Error: wrapError(err, n.NodeName()),
@@ -107,7 +271,7 @@ func (self *EthReader) GetCode(address string) (code []byte, err error) {
 		}()
 	}
 	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
+	for i := 0; i < len(nodes); i++ {
 		result := <-resCh
 		if result.Error == nil {
 			return result.Code, result.Error
@@ -117,37 +281,103 @@ func (self *EthReader) GetCode(address string) (code []byte, err error) {
 	return nil, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
 }
 
+type getStorageResponse struct {
+	Value common.Hash
+	Error error
+}
+
+// GetStorageAt returns the value of a storage slot of the address at the
+// given block
+func (self *EthReader) GetStorageAt(address string, slot common.Hash, block BlockRef) (value common.Hash, err error) {
+	return self.GetStorageAtContext(context.Background(), address, slot, block)
+}
+
+func (self *EthReader) GetStorageAtContext(ctx context.Context, address string, slot common.Hash, block BlockRef) (value common.Hash, err error) {
+	address, err = self.ResolveAddressAtContext(ctx, block, address)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	err = self.cached(self.stateCacheKey(ctx, block, "storage", address, slot.Hex()), &value, func() (bool, error) {
+		value, err = self.getStorageAt(ctx, address, slot, block)
+		return true, err
+	})
+	return value, err
+}
+
+func (self *EthReader) getStorageAt(ctx context.Context, address string, slot common.Hash, block BlockRef) (common.Hash, error) {
+	if self.isQuorumEnabled() {
+		value, err := self.readWithQuorum(ctx, block, func(n ContextEthereumNode, block BlockRef) (interface{}, error) {
+			return n.GetStorageAtContext(ctx, address, slot, block)
+		})
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return value.(common.Hash), nil
+	}
+	nodes := self.activeNodes()
+	resCh := make(chan getStorageResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
+		go func() {
+			var value common.Hash
+			err := self.callNode(ctx, n, func() (err error) {
+				value, err = n.GetStorageAtContext(ctx, address, slot, block)
+				return err
+			})
+			resCh <- getStorageResponse{
+				Value: value,
+				Error: wrapError(err, n.NodeName()),
+			}
+		}()
+	}
+	errs := []error{}
+	for i := 0; i < len(nodes); i++ {
+		result := <-resCh
+		if result.Error == nil {
+			return result.Value, result.Error
+		}
+		errs = append(errs, result.Error)
+	}
+	return common.Hash{}, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
+}
+
 func (self *EthReader) TxInfoFromHash(tx string) (eu.TxInfo, error) {
-	txObj, isPending, err := self.TransactionByHash(tx)
+	return self.TxInfoFromHashContext(context.Background(), tx)
+}
+
+func (self *EthReader) TxInfoFromHashContext(ctx context.Context, tx string) (eu.TxInfo, error) {
+	txObj, isPending, err := self.TransactionByHashContext(ctx, tx)
 	if err != nil {
-		return eu.TxInfo{"error", nil, nil, nil, nil}, err
+		return eu.TxInfo{Status: eu.TxStatusError}, err
 	}
 	if txObj == nil {
-		return eu.TxInfo{"notfound", nil, nil, nil, nil}, nil
+		return eu.TxInfo{Status: eu.TxStatusNotFound}, nil
 	} else {
 		if isPending {
-			return eu.TxInfo{"pending", txObj, nil, nil, nil}, nil
+			return eu.TxInfo{Status: eu.TxStatusPending, Tx: txObj}, nil
 		} else {
-			receipt, _ := self.TransactionReceipt(tx)
+			receipt, _ := self.TransactionReceiptContext(ctx, tx)
 			if receipt == nil {
-				return eu.TxInfo{"pending", txObj, nil, nil, nil}, nil
+				return eu.TxInfo{Status: eu.TxStatusPending, Tx: txObj}, nil
 			} else {
-				block, _ := self.HeaderByNumber(receipt.BlockNumber.Int64())
+				block, _ := self.HeaderByNumberContext(ctx, receipt.BlockNumber.Int64())
+				info := eu.TxInfo{
+					Status:      eu.TxStatusDone,
+					Tx:          txObj,
+					InternalTxs: []eu.InternalTx{},
+					Receipt:     receipt,
+					BlockHeader: block,
+				}
 				// only byzantium has status field at the moment
 				// mainnet, ropsten are byzantium, other chains such as
 				// devchain, kovan are not.
 				// if PostState is a hash, it is pre-byzantium and all
 				// txs with PostState are considered done
-				if len(receipt.PostState) == len(common.Hash{}) {
-					return eu.TxInfo{"done", txObj, []eu.InternalTx{}, receipt, block}, nil
-				} else {
-					if receipt.Status == 1 {
-						// successful tx
-						return eu.TxInfo{"done", txObj, []eu.InternalTx{}, receipt, block}, nil
-					}
+				if len(receipt.PostState) != len(common.Hash{}) && receipt.Status != 1 {
 					// failed tx
-					return eu.TxInfo{"reverted", txObj, []eu.InternalTx{}, receipt, block}, nil
+					info.Status = eu.TxStatusReverted
 				}
+				return info, nil
 			}
 		}
 	}
@@ -164,7 +394,15 @@ type ksresponse struct {
 }
 
 func (self *EthReader) RecommendedGasPriceFromKyberSwap() (low, average, fast float64, err error) {
-	resp, err := http.Get("https://production-cache.kyber.network/gasPrice")
+	return self.RecommendedGasPriceFromKyberSwapContext(context.Background())
+}
+
+func (self *EthReader) RecommendedGasPriceFromKyberSwapContext(ctx context.Context) (low, average, fast float64, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://production-cache.kyber.network/gasPrice", nil)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return 0, 0, 0, err
 	}
@@ -226,9 +464,13 @@ type gsresponse struct {
 
 // return gwei
 func (self *EthReader) RecommendedGasPrice() (float64, error) {
+	return self.RecommendedGasPriceContext(context.Background())
+}
+
+func (self *EthReader) RecommendedGasPriceContext(ctx context.Context) (float64, error) {
 	price, err := self.be.RecommendedGasPrice()
 	if err != nil {
-		priceWei, err := self.GetGasPriceWeiSuggestion()
+		priceWei, err := self.GetGasPriceWeiSuggestionContext(ctx)
 		if err != nil {
 			return 0, err
 		}
@@ -243,11 +485,20 @@ type getGasSuggestionResponse struct {
 }
 
 func (self *EthReader) GetGasPriceWeiSuggestion() (*big.Int, error) {
-	resCh := make(chan getGasSuggestionResponse, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
+	return self.GetGasPriceWeiSuggestionContext(context.Background())
+}
+
+func (self *EthReader) GetGasPriceWeiSuggestionContext(ctx context.Context) (*big.Int, error) {
+	nodes := self.activeNodes()
+	resCh := make(chan getGasSuggestionResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
 		go func() {
-			price, err := n.GetGasPriceSuggestion()
+			var price *big.Int
+			err := self.callNode(ctx, n, func() (err error) {
+				price, err = n.GetGasPriceSuggestionContext(ctx)
+				return err
+			})
 			resCh <- getGasSuggestionResponse{
 				GasPrice: price,
 				Error:    wrapError(err, n.NodeName()),
@@ -255,7 +506,39 @@ func (self *EthReader) GetGasPriceWeiSuggestion() (*big.Int, error) {
 		}()
 	}
 	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
+	for i := 0; i < len(nodes); i++ {
+		result := <-resCh
+		if result.Error == nil {
+			return result.GasPrice, result.Error
+		}
+		errs = append(errs, result.Error)
+	}
+	return nil, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
+}
+
+func (self *EthReader) GetGasTipCapWeiSuggestion() (*big.Int, error) {
+	return self.GetGasTipCapWeiSuggestionContext(context.Background())
+}
+
+func (self *EthReader) GetGasTipCapWeiSuggestionContext(ctx context.Context) (*big.Int, error) {
+	nodes := self.activeNodes()
+	resCh := make(chan getGasSuggestionResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
+		go func() {
+			var tip *big.Int
+			err := self.callNode(ctx, n, func() (err error) {
+				tip, err = n.GetGasTipCapSuggestionContext(ctx)
+				return err
+			})
+			resCh <- getGasSuggestionResponse{
+				GasPrice: tip,
+				Error:    wrapError(err, n.NodeName()),
+			}
+		}()
+	}
+	errs := []error{}
+	for i := 0; i < len(nodes); i++ {
 		result := <-resCh
 		if result.Error == nil {
 			return result.GasPrice, result.Error
@@ -265,17 +548,135 @@ func (self *EthReader) GetGasPriceWeiSuggestion() (*big.Int, error) {
 	return nil, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
 }
 
+// RecommendedDynamicFees returns the max priority fee and max fee per
+// gas in gwei to be used in a dynamic fee (EIP-1559) tx. See
+// RecommendedDynamicFeesWei, the gwei floats may lose precision.
+func (self *EthReader) RecommendedDynamicFees() (tipCapGwei, feeCapGwei float64, err error) {
+	return self.RecommendedDynamicFeesContext(context.Background())
+}
+
+func (self *EthReader) RecommendedDynamicFeesContext(ctx context.Context) (tipCapGwei, feeCapGwei float64, err error) {
+	tipCap, feeCap, err := self.RecommendedDynamicFeesWeiContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	return eu.BigToFloat(tipCap, 9), eu.BigToFloat(feeCap, 9), nil
+}
+
+// RecommendedDynamicFeesWei returns the max priority fee and max fee per
+// gas in wei to be used in a dynamic fee (EIP-1559) tx. The max fee is
+// 2 times the latest base fee plus the priority fee so the tx stays
+// valid through several blocks of base fee increase.
+func (self *EthReader) RecommendedDynamicFeesWei() (tipCap, feeCap *big.Int, err error) {
+	return self.RecommendedDynamicFeesWeiContext(context.Background())
+}
+
+func (self *EthReader) RecommendedDynamicFeesWeiContext(ctx context.Context) (tipCap, feeCap *big.Int, err error) {
+	tip, err := self.GetGasTipCapWeiSuggestionContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	header, err := self.HeaderByNumberContext(ctx, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.BaseFee == nil {
+		return nil, nil, fmt.Errorf("the chain doesn't support dynamic fee txs")
+	}
+	feeCap = big.NewInt(0).Add(
+		tip,
+		big.NewInt(0).Mul(header.BaseFee, big.NewInt(2)),
+	)
+	return tip, feeCap, nil
+}
+
+type getChainIDResponse struct {
+	ChainID int64
+	Error   error
+}
+
+func (self *EthReader) ChainID() (int64, error) {
+	return self.ChainIDContext(context.Background())
+}
+
+func (self *EthReader) ChainIDContext(ctx context.Context) (int64, error) {
+	nodes := self.activeNodes()
+	resCh := make(chan getChainIDResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
+		go func() {
+			var chainID int64
+			err := self.callNode(ctx, n, func() (err error) {
+				chainID, err = n.ChainIDContext(ctx)
+				return err
+			})
+			resCh <- getChainIDResponse{
+				ChainID: chainID,
+				Error:   wrapError(err, n.NodeName()),
+			}
+		}()
+	}
+	errs := []error{}
+	for i := 0; i < len(nodes); i++ {
+		result := <-resCh
+		if result.Error == nil {
+			return result.ChainID, result.Error
+		}
+		errs = append(errs, result.Error)
+	}
+	return 0, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
+}
+
 type getBalanceResponse struct {
 	Balance *big.Int
 	Error   error
 }
 
 func (self *EthReader) GetBalance(address string) (balance *big.Int, err error) {
-	resCh := make(chan getBalanceResponse, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
+	return self.GetBalanceContext(context.Background(), address)
+}
+
+func (self *EthReader) GetBalanceContext(ctx context.Context, address string) (balance *big.Int, err error) {
+	return self.GetBalanceAtContext(ctx, address, LatestBlock())
+}
+
+// GetBalanceAt returns the balance of the address at the given block
+func (self *EthReader) GetBalanceAt(address string, block BlockRef) (balance *big.Int, err error) {
+	return self.GetBalanceAtContext(context.Background(), address, block)
+}
+
+func (self *EthReader) GetBalanceAtContext(ctx context.Context, address string, block BlockRef) (balance *big.Int, err error) {
+	address, err = self.ResolveAddressAtContext(ctx, block, address)
+	if err != nil {
+		return nil, err
+	}
+	err = self.cached(self.stateCacheKey(ctx, block, "balance", address), &balance, func() (bool, error) {
+		balance, err = self.getBalanceAt(ctx, address, block)
+		return true, err
+	})
+	return balance, err
+}
+
+func (self *EthReader) getBalanceAt(ctx context.Context, address string, block BlockRef) (*big.Int, error) {
+	if self.isQuorumEnabled() {
+		value, err := self.readWithQuorum(ctx, block, func(n ContextEthereumNode, block BlockRef) (interface{}, error) {
+			return n.GetBalanceAtContext(ctx, address, block)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return value.(*big.Int), nil
+	}
+	nodes := self.activeNodes()
+	resCh := make(chan getBalanceResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
 		go func() {
-			balance, err := n.GetBalance(address)
+			var balance *big.Int
+			err := self.callNode(ctx, n, func() (err error) {
+				balance, err = n.GetBalanceAtContext(ctx, address, block)
+				return err
+			})
 			resCh <- getBalanceResponse{
 				Balance: balance,
 				Error:   wrapError(err, n.NodeName()),
@@ -283,7 +684,7 @@ func (self *EthReader) GetBalance(address string) (balance *big.Int, err error)
 		}()
 	}
 	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
+	for i := 0; i < len(nodes); i++ {
 		result := <-resCh
 		if result.Error == nil {
 			return result.Balance, result.Error
@@ -299,34 +700,59 @@ type getNonceResponse struct {
 }
 
 func (self *EthReader) GetMinedNonce(address string) (nonce uint64, err error) {
-	resCh := make(chan getNonceResponse, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
-		go func() {
-			nonce, err := n.GetMinedNonce(address)
-			resCh <- getNonceResponse{
-				Nonce: nonce,
-				Error: wrapError(err, n.NodeName()),
-			}
-		}()
-	}
-	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
-		result := <-resCh
-		if result.Error == nil {
-			return result.Nonce, result.Error
-		}
-		errs = append(errs, result.Error)
-	}
-	return 0, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
+	return self.GetMinedNonceContext(context.Background(), address)
+}
+
+func (self *EthReader) GetMinedNonceContext(ctx context.Context, address string) (nonce uint64, err error) {
+	return self.GetNonceAtContext(ctx, address, LatestBlock())
 }
 
 func (self *EthReader) GetPendingNonce(address string) (nonce uint64, err error) {
-	resCh := make(chan getNonceResponse, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
+	return self.GetPendingNonceContext(context.Background(), address)
+}
+
+func (self *EthReader) GetPendingNonceContext(ctx context.Context, address string) (nonce uint64, err error) {
+	return self.GetNonceAtContext(ctx, address, PendingBlock())
+}
+
+// GetNonceAt returns the number of txs sent by the address at the given
+// block
+func (self *EthReader) GetNonceAt(address string, block BlockRef) (nonce uint64, err error) {
+	return self.GetNonceAtContext(context.Background(), address, block)
+}
+
+func (self *EthReader) GetNonceAtContext(ctx context.Context, address string, block BlockRef) (nonce uint64, err error) {
+	address, err = self.ResolveAddressAtContext(ctx, block, address)
+	if err != nil {
+		return 0, err
+	}
+	err = self.cached(self.stateCacheKey(ctx, block, "nonce", address), &nonce, func() (bool, error) {
+		nonce, err = self.getNonceAt(ctx, address, block)
+		return true, err
+	})
+	return nonce, err
+}
+
+func (self *EthReader) getNonceAt(ctx context.Context, address string, block BlockRef) (uint64, error) {
+	if self.isQuorumEnabled() {
+		value, err := self.readWithQuorum(ctx, block, func(n ContextEthereumNode, block BlockRef) (interface{}, error) {
+			return n.GetNonceAtContext(ctx, address, block)
+		})
+		if err != nil {
+			return 0, err
+		}
+		return value.(uint64), nil
+	}
+	nodes := self.activeNodes()
+	resCh := make(chan getNonceResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
 		go func() {
-			nonce, err := n.GetPendingNonce(address)
+			var nonce uint64
+			err := self.callNode(ctx, n, func() (err error) {
+				nonce, err = n.GetNonceAtContext(ctx, address, block)
+				return err
+			})
 			resCh <- getNonceResponse{
 				Nonce: nonce,
 				Error: wrapError(err, n.NodeName()),
@@ -334,7 +760,7 @@ func (self *EthReader) GetPendingNonce(address string) (nonce uint64, err error)
 		}()
 	}
 	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
+	for i := 0; i < len(nodes); i++ {
 		result := <-resCh
 		if result.Error == nil {
 			return result.Nonce, result.Error
@@ -350,11 +776,31 @@ type transactionReceiptResponse struct {
 }
 
 func (self *EthReader) TransactionReceipt(txHash string) (receipt *types.Receipt, err error) {
-	resCh := make(chan transactionReceiptResponse, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
+	return self.TransactionReceiptContext(context.Background(), txHash)
+}
+
+func (self *EthReader) TransactionReceiptContext(ctx context.Context, txHash string) (receipt *types.Receipt, err error) {
+	err = self.cached(self.txCacheKey(ctx, "receipt", txHash), &receipt, func() (bool, error) {
+		receipt, err = self.transactionReceipt(ctx, txHash)
+		if err != nil {
+			return false, err
+		}
+		return self.isFinal(ctx, receipt.BlockNumber.Uint64()), nil
+	})
+	return receipt, err
+}
+
+func (self *EthReader) transactionReceipt(ctx context.Context, txHash string) (*types.Receipt, error) {
+	nodes := self.activeNodes()
+	resCh := make(chan transactionReceiptResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
 		go func() {
-			receipt, err := n.TransactionReceipt(txHash)
+			var receipt *types.Receipt
+			err := self.callNode(ctx, n, func() (err error) {
+				receipt, err = n.TransactionReceiptContext(ctx, txHash)
+				return err
+			})
 			resCh <- transactionReceiptResponse{
 				Receipt: receipt,
 				Error:   wrapError(err, n.NodeName()),
@@ -362,7 +808,7 @@ func (self *EthReader) TransactionReceipt(txHash string) (receipt *types.Receipt
 		}()
 	}
 	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
+	for i := 0; i < len(nodes); i++ {
 		result := <-resCh
 		if result.Error == nil {
 			return result.Receipt, result.Error
@@ -379,11 +825,33 @@ type transactionByHashResponse struct {
 }
 
 func (self *EthReader) TransactionByHash(txHash string) (tx *eu.Transaction, isPending bool, err error) {
-	resCh := make(chan transactionByHashResponse, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
+	return self.TransactionByHashContext(context.Background(), txHash)
+}
+
+func (self *EthReader) TransactionByHashContext(ctx context.Context, txHash string) (tx *eu.Transaction, isPending bool, err error) {
+	err = self.cached(self.txCacheKey(ctx, "tx", txHash), &tx, func() (bool, error) {
+		tx, isPending, err = self.transactionByHash(ctx, txHash)
+		if err != nil || isPending || tx.Extra.BlockNumber == nil {
+			return false, err
+		}
+		number, err := hexutil.DecodeUint64(*tx.Extra.BlockNumber)
+		return err == nil && self.isFinal(ctx, number), nil
+	})
+	return tx, isPending, err
+}
+
+func (self *EthReader) transactionByHash(ctx context.Context, txHash string) (*eu.Transaction, bool, error) {
+	nodes := self.activeNodes()
+	resCh := make(chan transactionByHashResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
 		go func() {
-			tx, ispending, err := n.TransactionByHash(txHash)
+			var tx *eu.Transaction
+			var ispending bool
+			err := self.callNode(ctx, n, func() (err error) {
+				tx, ispending, err = n.TransactionByHashContext(ctx, txHash)
+				return err
+			})
 			resCh <- transactionByHashResponse{
 				Tx:        tx,
 				IsPending: ispending,
@@ -392,7 +860,7 @@ func (self *EthReader) TransactionByHash(txHash string) (tx *eu.Transaction, isP
 		}()
 	}
 	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
+	for i := 0; i < len(nodes); i++ {
 		result := <-resCh
 		if result.Error == nil {
 			return result.Tx, result.IsPending, result.Error
@@ -407,20 +875,92 @@ type readContractToBytesResponse struct {
 	Error error
 }
 
+// ReadContractToBytes calls the contract at atBlock and returns the raw
+// returned data. atBlock <= 0 means the latest block.
 func (self *EthReader) ReadContractToBytes(atBlock int64, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) ([]byte, error) {
-	resCh := make(chan readContractToBytesResponse, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
+	return self.ReadContractToBytesContext(context.Background(), atBlock, from, caddr, abi, method, args...)
+}
+
+func (self *EthReader) ReadContractToBytesContext(ctx context.Context, atBlock int64, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) ([]byte, error) {
+	return self.ReadContractToBytesAtContext(ctx, BlockRefFromInt64(atBlock), from, caddr, abi, method, args...)
+}
+
+// ReadContractToBytesAt calls the contract at the given block and returns
+// the raw returned data. from and caddr can be ENS names, they are
+// resolved at the same block.
+func (self *EthReader) ReadContractToBytesAt(block BlockRef, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) ([]byte, error) {
+	return self.ReadContractToBytesAtContext(context.Background(), block, from, caddr, abi, method, args...)
+}
+
+func (self *EthReader) ReadContractToBytesAtContext(ctx context.Context, block BlockRef, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) ([]byte, error) {
+	data, err := abi.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	return self.CallContractAtContext(ctx, block, from, caddr, data)
+}
+
+// CallContractAt does an eth_call with the raw call data at the given
+// block
+func (self *EthReader) CallContractAt(block BlockRef, from string, caddr string, data []byte) ([]byte, error) {
+	return self.CallContractAtContext(context.Background(), block, from, caddr, data)
+}
+
+func (self *EthReader) CallContractAtContext(ctx context.Context, block BlockRef, from string, caddr string, data []byte) ([]byte, error) {
+	from, caddr, err := self.resolvePairAt(ctx, block, from, caddr)
+	if err != nil {
+		return nil, err
+	}
+	return self.callContractAt(ctx, block, from, caddr, data)
+}
+
+// readContractToBytesAt works as ReadContractToBytesAt without resolving
+// ENS names, it is used by the ENS resolution itself
+func (self *EthReader) readContractToBytesAt(ctx context.Context, block BlockRef, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) ([]byte, error) {
+	data, err := abi.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	return self.callContractAt(ctx, block, from, caddr, data)
+}
+
+func (self *EthReader) callContractAt(ctx context.Context, block BlockRef, from string, caddr string, data []byte) (returned []byte, err error) {
+	key := self.stateCacheKey(ctx, block, "call", from, caddr, hexutil.Encode(data))
+	err = self.cached(key, &returned, func() (bool, error) {
+		returned, err = self.callContract(ctx, block, from, caddr, data)
+		return true, err
+	})
+	return returned, err
+}
+
+func (self *EthReader) callContract(ctx context.Context, block BlockRef, from string, caddr string, data []byte) ([]byte, error) {
+	if self.isQuorumEnabled() {
+		value, err := self.readWithQuorum(ctx, block, func(n ContextEthereumNode, block BlockRef) (interface{}, error) {
+			return n.CallContractAtContext(ctx, block, from, caddr, data)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return value.([]byte), nil
+	}
+	nodes := self.activeNodes()
+	resCh := make(chan readContractToBytesResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
 		go func() {
-			data, err := n.ReadContractToBytes(atBlock, from, caddr, abi, method, args...)
+			var returned []byte
+			err := self.callNode(ctx, n, func() (err error) {
+				returned, err = n.CallContractAtContext(ctx, block, from, caddr, data)
+				return err
+			})
 			resCh <- readContractToBytesResponse{
-				Data:  data,
+				Data:  returned,
 				Error: wrapError(err, n.NodeName()),
 			}
 		}()
 	}
 	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
+	for i := 0; i < len(nodes); i++ {
 		result := <-resCh
 		if result.Error == nil {
 			return result.Data, result.Error
@@ -430,9 +970,44 @@ func (self *EthReader) ReadContractToBytes(atBlock int64, from string, caddr str
 	return nil, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
 }
 
+// callContractOnNode does the eth_call on n only so a batch of calls can
+// be spread over the nodes. It reads from every node when n is nil, when
+// quorum reads are enabled or when n can't be reached.
+func (self *EthReader) callContractOnNode(ctx context.Context, n ContextEthereumNode, block BlockRef, from string, caddr string, data []byte) (returned []byte, err error) {
+	if n == nil || self.isQuorumEnabled() {
+		return self.callContractAt(ctx, block, from, caddr, data)
+	}
+	key := self.stateCacheKey(ctx, block, "call", from, caddr, hexutil.Encode(data))
+	err = self.cached(key, &returned, func() (bool, error) {
+		err := self.callNode(ctx, n, func() (err error) {
+			returned, err = n.CallContractAtContext(ctx, block, from, caddr, data)
+			return err
+		})
+		if isNodeFailure(err) {
+			returned, err = self.callContract(ctx, block, from, caddr, data)
+			return true, err
+		}
+		return true, wrapError(err, n.NodeName())
+	})
+	return returned, err
+}
+
 func (self *EthReader) ReadHistoryContractWithABI(atBlock int64, result interface{}, caddr string, abi *abi.ABI, method string, args ...interface{}) error {
-	responseBytes, err := self.ReadContractToBytes(
-		int64(atBlock), DEFAULT_ADDRESS, caddr, abi, method, args...)
+	return self.ReadHistoryContractWithABIContext(context.Background(), atBlock, result, caddr, abi, method, args...)
+}
+
+func (self *EthReader) ReadHistoryContractWithABIContext(ctx context.Context, atBlock int64, result interface{}, caddr string, abi *abi.ABI, method string, args ...interface{}) error {
+	return self.ReadContractWithABIAtContext(ctx, BlockRefFromInt64(atBlock), result, caddr, abi, method, args...)
+}
+
+// ReadContractWithABIAt calls the contract at the given block and unpacks
+// the returned data into result
+func (self *EthReader) ReadContractWithABIAt(block BlockRef, result interface{}, caddr string, abi *abi.ABI, method string, args ...interface{}) error {
+	return self.ReadContractWithABIAtContext(context.Background(), block, result, caddr, abi, method, args...)
+}
+
+func (self *EthReader) ReadContractWithABIAtContext(ctx context.Context, block BlockRef, result interface{}, caddr string, abi *abi.ABI, method string, args ...interface{}) error {
+	responseBytes, err := self.ReadContractToBytesAtContext(ctx, block, DEFAULT_ADDRESS, caddr, abi, method, args...)
 	if err != nil {
 		return err
 	}
@@ -440,7 +1015,11 @@ func (self *EthReader) ReadHistoryContractWithABI(atBlock int64, result interfac
 }
 
 func (self *EthReader) ReadContractWithABIAndFrom(result interface{}, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) error {
-	responseBytes, err := self.ReadContractToBytes(-1, from, caddr, abi, method, args...)
+	return self.ReadContractWithABIAndFromContext(context.Background(), result, from, caddr, abi, method, args...)
+}
+
+func (self *EthReader) ReadContractWithABIAndFromContext(ctx context.Context, result interface{}, from string, caddr string, abi *abi.ABI, method string, args ...interface{}) error {
+	responseBytes, err := self.ReadContractToBytesAtContext(ctx, LatestBlock(), from, caddr, abi, method, args...)
 	if err != nil {
 		return err
 	}
@@ -448,54 +1027,102 @@ func (self *EthReader) ReadContractWithABIAndFrom(result interface{}, from strin
 }
 
 func (self *EthReader) ReadContractWithABI(result interface{}, caddr string, abi *abi.ABI, method string, args ...interface{}) error {
-	responseBytes, err := self.ReadContractToBytes(-1, DEFAULT_ADDRESS, caddr, abi, method, args...)
-	if err != nil {
-		return err
-	}
-	return abi.UnpackIntoInterface(result, method, responseBytes)
+	return self.ReadContractWithABIContext(context.Background(), result, caddr, abi, method, args...)
+}
+
+func (self *EthReader) ReadContractWithABIContext(ctx context.Context, result interface{}, caddr string, abi *abi.ABI, method string, args ...interface{}) error {
+	return self.ReadContractWithABIAtContext(ctx, LatestBlock(), result, caddr, abi, method, args...)
 }
 
 func (self *EthReader) ReadHistoryContract(atBlock int64, result interface{}, caddr string, method string, args ...interface{}) error {
-	abi, err := self.GetABI(caddr)
+	return self.ReadHistoryContractContext(context.Background(), atBlock, result, caddr, method, args...)
+}
+
+func (self *EthReader) ReadHistoryContractContext(ctx context.Context, atBlock int64, result interface{}, caddr string, method string, args ...interface{}) error {
+	return self.ReadContractAtContext(ctx, BlockRefFromInt64(atBlock), result, caddr, method, args...)
+}
+
+// ReadContractAt works as ReadContractWithABIAt with the ABI from the
+// block explorer
+func (self *EthReader) ReadContractAt(block BlockRef, result interface{}, caddr string, method string, args ...interface{}) error {
+	return self.ReadContractAtContext(context.Background(), block, result, caddr, method, args...)
+}
+
+func (self *EthReader) ReadContractAtContext(ctx context.Context, block BlockRef, result interface{}, caddr string, method string, args ...interface{}) error {
+	caddr, err := self.ResolveAddressAtContext(ctx, block, caddr)
 	if err != nil {
 		return err
 	}
-	return self.ReadHistoryContractWithABI(atBlock, result, caddr, abi, method, args...)
-}
-
-func (self *EthReader) ReadContract(result interface{}, caddr string, method string, args ...interface{}) error {
 	abi, err := self.GetABI(caddr)
 	if err != nil {
 		return err
 	}
-	return self.ReadContractWithABI(result, caddr, abi, method, args...)
+	return self.ReadContractWithABIAtContext(ctx, block, result, caddr, abi, method, args...)
+}
+
+func (self *EthReader) ReadContract(result interface{}, caddr string, method string, args ...interface{}) error {
+	return self.ReadContractContext(context.Background(), result, caddr, method, args...)
+}
+
+func (self *EthReader) ReadContractContext(ctx context.Context, result interface{}, caddr string, method string, args ...interface{}) error {
+	return self.ReadContractAtContext(ctx, LatestBlock(), result, caddr, method, args...)
 }
 
 func (self *EthReader) HistoryERC20Balance(atBlock int64, caddr string, user string) (*big.Int, error) {
-	abi := eu.GetERC20ABI()
-	result := big.NewInt(0)
-	err := self.ReadHistoryContractWithABI(atBlock, &result, caddr, abi, "balanceOf", eu.HexToAddress(user))
-	return result, err
+	return self.HistoryERC20BalanceContext(context.Background(), atBlock, caddr, user)
+}
+
+func (self *EthReader) HistoryERC20BalanceContext(ctx context.Context, atBlock int64, caddr string, user string) (*big.Int, error) {
+	return self.ERC20BalanceAtContext(ctx, BlockRefFromInt64(atBlock), caddr, user)
 }
 
 func (self *EthReader) ERC20Balance(caddr string, user string) (*big.Int, error) {
+	return self.ERC20BalanceContext(context.Background(), caddr, user)
+}
+
+func (self *EthReader) ERC20BalanceContext(ctx context.Context, caddr string, user string) (*big.Int, error) {
+	return self.ERC20BalanceAtContext(ctx, LatestBlock(), caddr, user)
+}
+
+func (self *EthReader) ERC20BalanceAt(block BlockRef, caddr string, user string) (*big.Int, error) {
+	return self.ERC20BalanceAtContext(context.Background(), block, caddr, user)
+}
+
+func (self *EthReader) ERC20BalanceAtContext(ctx context.Context, block BlockRef, caddr string, user string) (*big.Int, error) {
 	abi := eu.GetERC20ABI()
 	result := big.NewInt(0)
-	err := self.ReadContractWithABI(&result, caddr, abi, "balanceOf", eu.HexToAddress(user))
+	user, err := self.ResolveAddressAtContext(ctx, block, user)
+	if err != nil {
+		return result, err
+	}
+	err = self.ReadContractWithABIAtContext(ctx, block, &result, caddr, abi, "balanceOf", eu.HexToAddress(user))
 	return result, err
 }
 
 func (self *EthReader) HistoryERC20Decimal(atBlock int64, caddr string) (int64, error) {
-	abi := eu.GetERC20ABI()
-	var result uint8
-	err := self.ReadHistoryContractWithABI(atBlock, &result, caddr, abi, "decimals")
-	return int64(result), err
+	return self.HistoryERC20DecimalContext(context.Background(), atBlock, caddr)
+}
+
+func (self *EthReader) HistoryERC20DecimalContext(ctx context.Context, atBlock int64, caddr string) (int64, error) {
+	return self.ERC20DecimalAtContext(ctx, BlockRefFromInt64(atBlock), caddr)
 }
 
 func (self *EthReader) ERC20Decimal(caddr string) (int64, error) {
+	return self.ERC20DecimalContext(context.Background(), caddr)
+}
+
+func (self *EthReader) ERC20DecimalContext(ctx context.Context, caddr string) (int64, error) {
+	return self.ERC20DecimalAtContext(ctx, LatestBlock(), caddr)
+}
+
+func (self *EthReader) ERC20DecimalAt(block BlockRef, caddr string) (int64, error) {
+	return self.ERC20DecimalAtContext(context.Background(), block, caddr)
+}
+
+func (self *EthReader) ERC20DecimalAtContext(ctx context.Context, block BlockRef, caddr string) (int64, error) {
 	abi := eu.GetERC20ABI()
 	var result uint8
-	err := self.ReadContractWithABI(&result, caddr, abi, "decimals")
+	err := self.ReadContractWithABIAtContext(ctx, block, &result, caddr, abi, "decimals")
 	return int64(result), err
 }
 
@@ -505,11 +1132,37 @@ type headerByNumberResponse struct {
 }
 
 func (self *EthReader) HeaderByNumber(number int64) (*types.Header, error) {
-	resCh := make(chan headerByNumberResponse, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
+	return self.HeaderByNumberContext(context.Background(), number)
+}
+
+func (self *EthReader) HeaderByNumberContext(ctx context.Context, number int64) (header *types.Header, err error) {
+	// only final headers are stored so the head is read to check
+	// finality only when the header is not cached yet
+	key := ""
+	if number >= 0 {
+		key = self.cacheKey(ctx, "header", strconv.FormatInt(number, 10))
+	}
+	err = self.cached(key, &header, func() (bool, error) {
+		header, err = self.headerByNumber(ctx, number)
+		if err != nil || key == "" {
+			return false, err
+		}
+		return self.isFinal(ctx, uint64(number)), nil
+	})
+	return header, err
+}
+
+func (self *EthReader) headerByNumber(ctx context.Context, number int64) (*types.Header, error) {
+	nodes := self.activeNodes()
+	resCh := make(chan headerByNumberResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
 		go func() {
-			header, err := n.HeaderByNumber(number)
+			var header *types.Header
+			err := self.callNode(ctx, n, func() (err error) {
+				header, err = n.HeaderByNumberContext(ctx, number)
+				return err
+			})
 			resCh <- headerByNumberResponse{
 				Header: header,
 				Error:  wrapError(err, n.NodeName()),
@@ -517,7 +1170,7 @@ func (self *EthReader) HeaderByNumber(number int64) (*types.Header, error) {
 		}()
 	}
 	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
+	for i := 0; i < len(nodes); i++ {
 		result := <-resCh
 		if result.Error == nil {
 			return result.Header, result.Error
@@ -528,22 +1181,34 @@ func (self *EthReader) HeaderByNumber(number int64) (*types.Header, error) {
 }
 
 func (self *EthReader) HistoryERC20Allowance(atBlock int64, caddr string, owner string, spender string) (*big.Int, error) {
-	abi := eu.GetERC20ABI()
-	result := big.NewInt(0)
-	err := self.ReadHistoryContractWithABI(
-		atBlock,
-		&result, caddr, abi,
-		"allowance",
-		eu.HexToAddress(owner),
-		eu.HexToAddress(spender),
-	)
-	return result, err
+	return self.HistoryERC20AllowanceContext(context.Background(), atBlock, caddr, owner, spender)
+}
+
+func (self *EthReader) HistoryERC20AllowanceContext(ctx context.Context, atBlock int64, caddr string, owner string, spender string) (*big.Int, error) {
+	return self.ERC20AllowanceAtContext(ctx, BlockRefFromInt64(atBlock), caddr, owner, spender)
 }
 
 func (self *EthReader) ERC20Allowance(caddr string, owner string, spender string) (*big.Int, error) {
+	return self.ERC20AllowanceContext(context.Background(), caddr, owner, spender)
+}
+
+func (self *EthReader) ERC20AllowanceContext(ctx context.Context, caddr string, owner string, spender string) (*big.Int, error) {
+	return self.ERC20AllowanceAtContext(ctx, LatestBlock(), caddr, owner, spender)
+}
+
+func (self *EthReader) ERC20AllowanceAt(block BlockRef, caddr string, owner string, spender string) (*big.Int, error) {
+	return self.ERC20AllowanceAtContext(context.Background(), block, caddr, owner, spender)
+}
+
+func (self *EthReader) ERC20AllowanceAtContext(ctx context.Context, block BlockRef, caddr string, owner string, spender string) (*big.Int, error) {
 	abi := eu.GetERC20ABI()
 	result := big.NewInt(0)
-	err := self.ReadContractWithABI(
+	owner, spender, err := self.resolvePairAt(ctx, block, owner, spender)
+	if err != nil {
+		return result, err
+	}
+	err = self.ReadContractWithABIAtContext(ctx,
+		block,
 		&result, caddr, abi,
 		"allowance",
 		eu.HexToAddress(owner),
@@ -553,41 +1218,31 @@ func (self *EthReader) ERC20Allowance(caddr string, owner string, spender string
 }
 
 func (self *EthReader) AddressFromContract(contract string, method string) (*common.Address, error) {
+	return self.AddressFromContractContext(context.Background(), contract, method)
+}
+
+func (self *EthReader) AddressFromContractContext(ctx context.Context, contract string, method string) (*common.Address, error) {
 	result := common.Address{}
-	err := self.ReadContract(&result, contract, method)
+	err := self.ReadContractContext(ctx, &result, contract, method)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-type getLogsResponse struct {
-	Logs  []types.Log
-	Error error
-}
-
 // if toBlock < 0, it will query to the latest block
+// GetLogs returns the logs with topic as the first topic. A negative
+// toBlock means the latest block. See FilterLogs for richer filters.
 func (self *EthReader) GetLogs(fromBlock, toBlock int, addresses []string, topic string) ([]types.Log, error) {
-	resCh := make(chan getLogsResponse, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
-		go func() {
-			logs, err := n.GetLogs(fromBlock, toBlock, addresses, topic)
-			resCh <- getLogsResponse{
-				Logs:  logs,
-				Error: wrapError(err, n.NodeName()),
-			}
-		}()
-	}
-	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
-		result := <-resCh
-		if result.Error == nil {
-			return result.Logs, result.Error
-		}
-		errs = append(errs, result.Error)
+	return self.GetLogsContext(context.Background(), fromBlock, toBlock, addresses, topic)
+}
+
+func (self *EthReader) GetLogsContext(ctx context.Context, fromBlock, toBlock int, addresses []string, topic string) ([]types.Log, error) {
+	addresses, err := self.resolveAddressesAt(ctx, BlockRefFromInt64(int64(toBlock)), addresses)
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
+	return self.FilterLogsContext(ctx, logsQuery(fromBlock, toBlock, addresses, topic))
 }
 
 type getBlockResponse struct {
@@ -596,11 +1251,23 @@ type getBlockResponse struct {
 }
 
 func (self *EthReader) CurrentBlock() (uint64, error) {
-	resCh := make(chan getBlockResponse, len(self.nodes))
-	for i, _ := range self.nodes {
-		n := self.nodes[i]
+	return self.CurrentBlockContext(context.Background())
+}
+
+func (self *EthReader) CurrentBlockContext(ctx context.Context) (uint64, error) {
+	nodes := self.activeNodes()
+	resCh := make(chan getBlockResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
 		go func() {
-			block, err := n.CurrentBlock()
+			var block uint64
+			err := self.callNode(ctx, n, func() (err error) {
+				block, err = n.CurrentBlockContext(ctx)
+				return err
+			})
+			if err == nil {
+				self.health.recordHead(n.NodeName(), block)
+			}
 			resCh <- getBlockResponse{
 				Block: block,
 				Error: wrapError(err, n.NodeName()),
@@ -608,7 +1275,7 @@ func (self *EthReader) CurrentBlock() (uint64, error) {
 		}()
 	}
 	errs := []error{}
-	for i := 0; i < len(self.nodes); i++ {
+	for i := 0; i < len(nodes); i++ {
 		result := <-resCh
 		if result.Error == nil {
 			return result.Block, result.Error
@@ -619,6 +1286,10 @@ func (self *EthReader) CurrentBlock() (uint64, error) {
 }
 
 func (self *EthReader) GetABIString(address string) (string, error) {
+	address, err := self.ResolveAddress(address)
+	if err != nil {
+		return "", err
+	}
 	return self.be.GetABIString(address)
 }
 
@@ -634,3 +1305,19 @@ func (self *EthReader) GetABI(address string) (*abi.ABI, error) {
 	}
 	return &result, nil
 }
+
+// GetABIOrBuiltin returns the ABI of the contract from the block explorer
+// and falls back to the builtin ABI named builtinName (see
+// ethutils.BuiltinABINames) when the explorer doesn't have it or
+// can't be reached. Builtin ABIs are shared and must not be modified.
+func (self *EthReader) GetABIOrBuiltin(address string, builtinName string) (*abi.ABI, error) {
+	result, err := self.GetABI(address)
+	if err == nil {
+		return result, nil
+	}
+	builtin, berr := eu.GetBuiltinABI(builtinName)
+	if berr != nil {
+		return nil, fmt.Errorf("getting abi from explorer failed: %s, %w", err, berr)
+	}
+	return builtin, nil
+}
@@ -1,41 +1,149 @@
 package reader
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/tranvictor/ethutils"
 )
 
 var DO_NOTHING_MC_ONE_RESULT_HANDLER MCOneResultHandler = func(result interface{}) error { return nil }
 
+// MULTICALL3_ADDRESS is the address Multicall3 is deployed at on most
+// chains
+const MULTICALL3_ADDRESS string = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+const (
+	// DEFAULT_MC_MAX_CHUNK_CALLS is the number of calls sent in one
+	// eth_call by default
+	DEFAULT_MC_MAX_CHUNK_CALLS int = 500
+	// DEFAULT_MC_MAX_CHUNK_BYTES is the total call data of the calls sent
+	// in one eth_call by default
+	DEFAULT_MC_MAX_CHUNK_BYTES int = 128 * 1024
+	// DEFAULT_MC_PARALLELISM is the number of eth_calls sent at the same
+	// time by default
+	DEFAULT_MC_PARALLELISM int = 4
+)
+
+// messages of the errors nodes return when an eth_call needs too much gas
+// or its request or response is too large
+var mcChunkTooLargeMessages = []string{
+	"out of gas",
+	"gas required exceeds",
+	"exceeds block gas limit",
+	"gas limit reached",
+	"request entity too large",
+	"payload too large",
+	"response size",
+	"response too large",
+	"exceeds the configured cap",
+}
+
+func isMCChunkTooLargeError(err error) bool {
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusRequestEntityTooLarge {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, m := range mcChunkTooLargeMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// messages of the errors nodes return when they don't have the block or
+// the state a call is read at
+var mcMissingBlockMessages = []string{
+	"header not found",
+	"unknown block",
+	"block not found",
+	"missing trie node",
+}
+
+func isMCMissingBlockError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, m := range mcMissingBlockMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
 type MCOneResultHandler func(result interface{}) error
 
+// MCCallStatus is the outcome of one call of a MultipleCall
+type MCCallStatus struct {
+	Success bool
+	// RevertReason is the decoded reason of a reverted call, empty if the
+	// call reverted without data
+	RevertReason string
+	// Error is why the result couldn't be filled: the call reverted or its
+	// return data couldn't be unpacked
+	Error error
+}
+
+// MCResultHandler is called with the result of a call and its status.
+// The result is only filled when status.Success is true.
+type MCResultHandler func(result interface{}, status MCCallStatus) error
+
 type MultipleCall struct {
 	r        *EthReader
 	contract string
 	mcABI    *abi.ABI
-	results  []interface{}
-	caddrs   []string
-	abis     []*abi.ABI
-	methods  []string
-	argLists [][]interface{}
-	hooks    []MCOneResultHandler
+	// mcMethod is "aggregate" for the legacy Multicall contract and
+	// "aggregate3" for Multicall3
+	mcMethod      string
+	results       []interface{}
+	caddrs        []string
+	abis          []*abi.ABI
+	methods       []string
+	argLists      [][]interface{}
+	hooks         []MCResultHandler
+	allowFailures []bool
+	statuses      []MCCallStatus
+
+	// see SetChunkLimits and SetParallelism
+	maxChunkCalls int
+	maxChunkBytes int
+	parallelism   int
 }
 
 func NewMultiCall(r *EthReader, mcContract string) *MultipleCall {
 	return &MultipleCall{
-		r,
-		mcContract,
-		ethutils.GetMultiCallABI(),
-		[]interface{}{},
-		[]string{},
-		[]*abi.ABI{},
-		[]string{},
-		[][]interface{}{},
-		[]MCOneResultHandler{},
+		r:             r,
+		contract:      mcContract,
+		mcABI:         ethutils.GetMultiCallABI(),
+		mcMethod:      "aggregate",
+		maxChunkCalls: DEFAULT_MC_MAX_CHUNK_CALLS,
+		maxChunkBytes: DEFAULT_MC_MAX_CHUNK_BYTES,
+		parallelism:   DEFAULT_MC_PARALLELISM,
+	}
+}
+
+// NewMultiCall3 returns a MultipleCall using aggregate3 of the Multicall3
+// contract at mcContract, usually MULTICALL3_ADDRESS. Unlike the legacy
+// Multicall, calls registered with allowFailure can revert without
+// failing the others.
+func NewMultiCall3(r *EthReader, mcContract string) *MultipleCall {
+	return &MultipleCall{
+		r:             r,
+		contract:      mcContract,
+		mcABI:         ethutils.GetMultiCall3ABI(),
+		mcMethod:      "aggregate3",
+		maxChunkCalls: DEFAULT_MC_MAX_CHUNK_CALLS,
+		maxChunkBytes: DEFAULT_MC_MAX_CHUNK_BYTES,
+		parallelism:   DEFAULT_MC_PARALLELISM,
 	}
 }
 
@@ -46,6 +154,35 @@ func (mc *MultipleCall) RegisterWithHook(
 	abi *abi.ABI,
 	method string,
 	args ...interface{},
+) *MultipleCall {
+	return mc.RegisterWithStatusHook(
+		result,
+		func(result interface{}, status MCCallStatus) error {
+			if !status.Success {
+				return nil
+			}
+			return hook(result)
+		},
+		false,
+		caddr,
+		abi,
+		method,
+		args...,
+	)
+}
+
+// RegisterWithStatusHook registers a call whose hook gets its status. If
+// allowFailure is true, the call reverting or returning data that can't
+// be unpacked doesn't fail Do, it is reported in the status instead.
+// Only Multicall3 supports allowFailure.
+func (mc *MultipleCall) RegisterWithStatusHook(
+	result interface{},
+	hook MCResultHandler,
+	allowFailure bool,
+	caddr string,
+	abi *abi.ABI,
+	method string,
+	args ...interface{},
 ) *MultipleCall {
 	mc.results = append(mc.results, result)
 	mc.caddrs = append(mc.caddrs, caddr)
@@ -53,9 +190,56 @@ func (mc *MultipleCall) RegisterWithHook(
 	mc.methods = append(mc.methods, method)
 	mc.argLists = append(mc.argLists, args)
 	mc.hooks = append(mc.hooks, hook)
+	mc.allowFailures = append(mc.allowFailures, allowFailure)
 	return mc
 }
 
+// RegisterAllowFailure registers a call that can fail without failing the
+// others, see RegisterWithStatusHook
+func (mc *MultipleCall) RegisterAllowFailure(
+	result interface{},
+	caddr string,
+	abi *abi.ABI,
+	method string,
+	args ...interface{},
+) *MultipleCall {
+	return mc.RegisterWithStatusHook(
+		result,
+		func(result interface{}, status MCCallStatus) error { return nil },
+		true,
+		caddr,
+		abi,
+		method,
+		args...,
+	)
+}
+
+// SetChunkLimits bounds the calls sent in one eth_call by their number
+// and the size of their call data, 0 means no bound. The calls are split
+// in chunks read at the same block and a chunk the nodes reject for
+// needing too much gas or being too large is split again in halves.
+// There is no gas limit per chunk: eth_calls are sent without gas so
+// every node applies its own cap (50M gas by default on geth) and a
+// chunk over it fails with out of gas and is split.
+func (mc *MultipleCall) SetChunkLimits(maxCalls int, maxCallDataBytes int) *MultipleCall {
+	mc.maxChunkCalls = maxCalls
+	mc.maxChunkBytes = maxCallDataBytes
+	return mc
+}
+
+// SetParallelism sets how many chunks are read at the same time. Chunks
+// are spread over the nodes of the reader.
+func (mc *MultipleCall) SetParallelism(n int) *MultipleCall {
+	mc.parallelism = n
+	return mc
+}
+
+// Statuses returns the status of every registered call, in the order they
+// were registered, after Do
+func (mc *MultipleCall) Statuses() []MCCallStatus {
+	return mc.statuses
+}
+
 func (mc *MultipleCall) Register(
 	result interface{},
 	caddr string,
@@ -83,52 +267,336 @@ type call struct {
 	CallData []byte
 }
 
-func (mc *MultipleCall) callMCContract(atBlock int64) (block int64, err error) {
-	res := multicallres{}
+type call3 struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
 
-	calls := []call{}
-	for i, caddr := range mc.caddrs {
+type call3result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+func (mc *MultipleCall) packCalls() ([][]byte, error) {
+	result := [][]byte{}
+	for i, _ := range mc.caddrs {
 		data, err := mc.abis[i].Pack(mc.methods[i], mc.argLists[i]...)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-
-		calls = append(calls, call{ethutils.HexToAddress(caddr), data})
+		result = append(result, data)
 	}
+	return result, nil
+}
 
-	err = mc.r.ReadHistoryContract(
-		atBlock,
-		&res,
-		mc.contract,
-		"aggregate",
-		calls,
-	)
+// chunks splits the calls in ranges [from, to) within the chunk limits
+func (mc *MultipleCall) chunks(datas [][]byte) [][2]int {
+	result := [][2]int{}
+	from, size := 0, 0
+	for i, data := range datas {
+		full := (mc.maxChunkCalls > 0 && i-from >= mc.maxChunkCalls) ||
+			(mc.maxChunkBytes > 0 && size+len(data) > mc.maxChunkBytes)
+		if full && i > from {
+			result = append(result, [2]int{from, i})
+			from, size = i, 0
+		}
+		size += len(data)
+	}
+	if from < len(datas) || len(datas) == 0 {
+		result = append(result, [2]int{from, len(datas)})
+	}
+	return result
+}
 
+// callChunk reads the calls [from, to) in one eth_call on n and fills
+// their results and statuses
+func (mc *MultipleCall) callChunk(ctx context.Context, n ContextEthereumNode, block BlockRef, mcAddr string, datas [][]byte, from, to int) (int64, error) {
+	if mc.mcMethod == "aggregate3" {
+		return mc.callMC3Chunk(ctx, n, block, mcAddr, datas, from, to)
+	}
+	calls := []call{}
+	for i := from; i < to; i++ {
+		calls = append(calls, call{ethutils.HexToAddress(mc.caddrs[i]), datas[i]})
+	}
+	data, err := mc.mcABI.Pack("aggregate", calls)
+	if err != nil {
+		return 0, err
+	}
+	returned, err := mc.r.callContractOnNode(ctx, n, block, DEFAULT_ADDRESS, mcAddr, data)
+	if err != nil {
+		return 0, fmt.Errorf("reading mc.aggregate failed: %w", err)
+	}
+	res := multicallres{}
+	err = mc.mcABI.UnpackIntoInterface(&res, "aggregate", returned)
 	if err != nil {
 		return 0, fmt.Errorf("reading mc.aggregate failed: %w", err)
 	}
+	if len(res.ReturnData) != len(calls) {
+		return 0, fmt.Errorf("mc.aggregate returned %d results for %d calls", len(res.ReturnData), len(calls))
+	}
 
-	for i, _ := range mc.results {
+	for i := from; i < to; i++ {
 		err = mc.abis[i].UnpackIntoInterface(
 			mc.results[i],
 			mc.methods[i],
-			res.ReturnData[i],
+			res.ReturnData[i-from],
 		)
 		if err != nil {
 			return 0, fmt.Errorf("unpacking call index %d failed: %w", i, err)
 		}
+		mc.statuses[i] = MCCallStatus{Success: true}
 	}
 	return res.BlockNumber.Int64(), nil
 }
 
+// callMC3Chunk calls aggregate3 with getBlockNumber of the Multicall3
+// contract as the last call, aggregate3 doesn't return the block number
+func (mc *MultipleCall) callMC3Chunk(ctx context.Context, n ContextEthereumNode, block BlockRef, mcAddr string, datas [][]byte, from, to int) (int64, error) {
+	// every call is allowed to fail so a revert comes back with its
+	// reason instead of reverting aggregate3, allowFailures is enforced
+	// below
+	calls := []call3{}
+	for i := from; i < to; i++ {
+		calls = append(calls, call3{ethutils.HexToAddress(mc.caddrs[i]), true, datas[i]})
+	}
+	blockData, err := mc.mcABI.Pack("getBlockNumber")
+	if err != nil {
+		return 0, err
+	}
+	calls = append(calls, call3{ethutils.HexToAddress(mcAddr), false, blockData})
+	data, err := mc.mcABI.Pack("aggregate3", calls)
+	if err != nil {
+		return 0, err
+	}
+	returned, err := mc.r.callContractOnNode(ctx, n, block, DEFAULT_ADDRESS, mcAddr, data)
+	if err != nil {
+		return 0, fmt.Errorf("reading mc.aggregate3 failed: %w", err)
+	}
+	res := []call3result{}
+	err = mc.mcABI.UnpackIntoInterface(&res, "aggregate3", returned)
+	if err != nil {
+		return 0, fmt.Errorf("reading mc.aggregate3 failed: %w", err)
+	}
+	if len(res) != len(calls) {
+		return 0, fmt.Errorf("mc.aggregate3 returned %d results for %d calls", len(res), len(calls))
+	}
+
+	for i := from; i < to; i++ {
+		r := res[i-from]
+		status := MCCallStatus{Success: r.Success}
+		if status.Success {
+			status.Error = mc.abis[i].UnpackIntoInterface(
+				mc.results[i],
+				mc.methods[i],
+				r.ReturnData,
+			)
+			if status.Error != nil {
+				status.Success = false
+				status.Error = fmt.Errorf("unpacking call index %d failed: %w", i, status.Error)
+			}
+		} else {
+			reason, err := ethutils.DecodeRevertReason(r.ReturnData)
+			if err != nil {
+				reason = "0x" + common.Bytes2Hex(r.ReturnData)
+			}
+			status.RevertReason = reason
+			if reason == "" {
+				status.Error = fmt.Errorf("call index %d reverted", i)
+			} else {
+				status.Error = fmt.Errorf("call index %d reverted: %s", i, reason)
+			}
+		}
+		if !status.Success && !mc.allowFailures[i] {
+			return 0, status.Error
+		}
+		mc.statuses[i] = status
+	}
+
+	blockNumber := big.NewInt(0)
+	err = mc.mcABI.UnpackIntoInterface(&blockNumber, "getBlockNumber", res[len(res)-1].ReturnData)
+	if err != nil {
+		return 0, fmt.Errorf("unpacking the block number failed: %w", err)
+	}
+	return blockNumber.Int64(), nil
+}
+
+// runChunk reads the calls [from, to), splitting them in halves when the
+// nodes reject them for needing too much gas or being too large. The
+// second half is read at the block of the first one.
+func (mc *MultipleCall) runChunk(ctx context.Context, n ContextEthereumNode, block BlockRef, mcAddr string, datas [][]byte, from, to int) (int64, error) {
+	blockNumber, err := mc.callChunk(ctx, n, block, mcAddr, datas, from, to)
+	if err == nil || to-from <= 1 || !isMCChunkTooLargeError(err) {
+		return blockNumber, err
+	}
+	mid := from + (to-from)/2
+	first, err := mc.runChunk(ctx, n, block, mcAddr, datas, from, mid)
+	if err != nil {
+		return 0, err
+	}
+	second, err := mc.runChunk(ctx, n, BlockRefFromInt64(first), mcAddr, datas, mid, to)
+	if err != nil {
+		return 0, err
+	}
+	if first != second {
+		return 0, fmt.Errorf("calls were read at blocks %d and %d", first, second)
+	}
+	return first, nil
+}
+
+type mcChunkResponse struct {
+	Block int64
+	Error error
+}
+
+type mcHeadResponse struct {
+	Node  ContextEthereumNode
+	Head  uint64
+	Error error
+}
+
+// lowestHead returns the nodes that answered their current block and the
+// lowest of those blocks
+func (mc *MultipleCall) lowestHead(ctx context.Context, nodes []ContextEthereumNode) ([]ContextEthereumNode, int64, error) {
+	resCh := make(chan mcHeadResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
+		go func() {
+			var head uint64
+			err := mc.r.callNode(ctx, n, func() (err error) {
+				head, err = n.CurrentBlockContext(ctx)
+				return err
+			})
+			resCh <- mcHeadResponse{
+				Node:  n,
+				Head:  head,
+				Error: wrapError(err, n.NodeName()),
+			}
+		}()
+	}
+	heads := map[string]uint64{}
+	errs := []error{}
+	for i := 0; i < len(nodes); i++ {
+		result := <-resCh
+		if result.Error != nil {
+			errs = append(errs, result.Error)
+			continue
+		}
+		heads[result.Node.NodeName()] = result.Head
+	}
+	if len(heads) == 0 {
+		return nil, 0, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
+	}
+	result := []ContextEthereumNode{}
+	lowest := int64(-1)
+	for _, n := range nodes {
+		head, found := heads[n.NodeName()]
+		if !found {
+			continue
+		}
+		result = append(result, n)
+		if lowest < 0 || int64(head) < lowest {
+			lowest = int64(head)
+		}
+	}
+	return result, lowest, nil
+}
+
+func (mc *MultipleCall) callMCContract(ctx context.Context, atBlock int64) (block int64, err error) {
+	if mc.mcMethod != "aggregate3" {
+		for i, allowFailure := range mc.allowFailures {
+			if allowFailure {
+				return 0, fmt.Errorf("call index %d allows failure which needs Multicall3, see NewMultiCall3", i)
+			}
+		}
+	}
+	datas, err := mc.packCalls()
+	if err != nil {
+		return 0, err
+	}
+	chunks := mc.chunks(datas)
+
+	// a single chunk is read from every node, chunks are spread over
+	// the nodes otherwise
+	nodes := []ContextEthereumNode{nil}
+	if len(chunks) > 1 {
+		nodes = mc.r.activeNodes()
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].NodeName() < nodes[j].NodeName() })
+		if atBlock <= 0 {
+			// every chunk must be read at the same block, one that all
+			// the nodes reading them have
+			nodes, atBlock, err = mc.lowestHead(ctx, nodes)
+			if err != nil {
+				return 0, fmt.Errorf("couldn't get the block to read the calls at: %w", err)
+			}
+		}
+	}
+	blockRef := BlockRefFromInt64(atBlock)
+	mcAddr, err := mc.r.ResolveAddressAtContext(ctx, blockRef, mc.contract)
+	if err != nil {
+		return 0, err
+	}
+	mc.statuses = make([]MCCallStatus, len(mc.results))
+	parallelism := mc.parallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+	// stops the other chunks when one fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sem := make(chan struct{}, parallelism)
+	resChs := []chan mcChunkResponse{}
+	for i, _ := range chunks {
+		chunk := chunks[i]
+		first := i % len(nodes)
+		resCh := make(chan mcChunkResponse, 1)
+		resChs = append(resChs, resCh)
+		go func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			var blockNumber int64
+			var err error
+			// a node without the block, such as one behind the block
+			// given to Do, leaves the chunk to the next nodes
+			for j := 0; j < len(nodes); j++ {
+				n := nodes[(first+j)%len(nodes)]
+				blockNumber, err = mc.runChunk(ctx, n, blockRef, mcAddr, datas, chunk[0], chunk[1])
+				if err == nil || !isMCMissingBlockError(err) {
+					break
+				}
+			}
+			resCh <- mcChunkResponse{
+				Block: blockNumber,
+				Error: err,
+			}
+		}()
+	}
+	for i, resCh := range resChs {
+		result := <-resCh
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		if i == 0 {
+			block = result.Block
+		} else if result.Block != block {
+			return 0, fmt.Errorf("chunks were read at blocks %d and %d", block, result.Block)
+		}
+	}
+	return block, nil
+}
+
 func (mc *MultipleCall) Do(atBlock int64) (block int64, err error) {
-	block, err = mc.callMCContract(atBlock)
+	return mc.DoContext(context.Background(), atBlock)
+}
+
+func (mc *MultipleCall) DoContext(ctx context.Context, atBlock int64) (block int64, err error) {
+	block, err = mc.callMCContract(ctx, atBlock)
 	if err != nil {
 		return 0, fmt.Errorf("calling mc contract failed: %w", err)
 	}
 
 	for i, result := range mc.results {
-		err = mc.hooks[i](result)
+		err = mc.hooks[i](result, mc.statuses[i])
 		if err != nil {
 			return 0, fmt.Errorf("calling hook at index %d failed: %w", i, err)
 		}
@@ -0,0 +1,112 @@
+package reader
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	eu "github.com/tranvictor/ethutils"
+)
+
+func TestMultiCallChunks(t *testing.T) {
+	sizes := func(ns ...int) [][]byte {
+		result := [][]byte{}
+		for _, n := range ns {
+			result = append(result, make([]byte, n))
+		}
+		return result
+	}
+	cases := []struct {
+		name     string
+		maxCalls int
+		maxBytes int
+		datas    [][]byte
+		want     [][2]int
+	}{
+		{"no calls", 10, 0, sizes(), [][2]int{{0, 0}}},
+		{"no limits", 0, 0, sizes(4, 4, 4), [][2]int{{0, 3}}},
+		{"under the limits", 3, 12, sizes(4, 4, 4), [][2]int{{0, 3}}},
+		{"by calls", 2, 0, sizes(4, 4, 4, 4, 4), [][2]int{{0, 2}, {2, 4}, {4, 5}}},
+		{"by bytes", 0, 10, sizes(4, 4, 4, 4), [][2]int{{0, 2}, {2, 4}}},
+		{"by calls and bytes", 2, 10, sizes(8, 4, 1, 1, 1), [][2]int{{0, 1}, {1, 3}, {3, 5}}},
+		{"call over the byte limit", 0, 10, sizes(4, 20, 4), [][2]int{{0, 1}, {1, 2}, {2, 3}}},
+	}
+	for _, c := range cases {
+		mc := NewMultiCall(nil, "").SetChunkLimits(c.maxCalls, c.maxBytes)
+		if got := mc.chunks(c.datas); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: chunks = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+// aggregateNode answers aggregate calls of balanceOf(address) with the
+// address as the balance, at its head or the block asked for and fails
+// with header not found for blocks after its head
+func aggregateNode(name string, head uint64) *fakeNode {
+	n := newFakeNode(name, head)
+	mcABI := eu.GetMultiCallABI()
+	n.call = func(block BlockRef, caddr string, data []byte) ([]byte, error) {
+		number := head
+		if block.number != nil {
+			number = block.number.Uint64()
+		}
+		if number > head {
+			return nil, rpcError("header not found")
+		}
+		args, err := mcABI.Methods["aggregate"].Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		calls := reflect.ValueOf(args[0])
+		returns := [][]byte{}
+		for i := 0; i < calls.Len(); i++ {
+			callData := calls.Index(i).FieldByName("CallData").Bytes()
+			returns = append(returns, callData[len(callData)-32:])
+		}
+		return mcABI.Methods["aggregate"].Outputs.Pack(new(big.Int).SetUint64(number), returns)
+	}
+	return n
+}
+
+func TestMultiCallPinsChunksToOneBlock(t *testing.T) {
+	cases := []struct {
+		name      string
+		atBlock   int64
+		wantBlock int64
+	}{
+		// latest is the lowest head so every node has it
+		{"latest", -1, 95},
+		{"zero is latest", 0, 95},
+		// the chunks of the lagging node go to the other one
+		{"block after a node head", 98, 98},
+		{"old block", 50, 50},
+	}
+	for _, c := range cases {
+		ahead := aggregateNode("ahead", 100)
+		behind := aggregateNode("behind", 95)
+		r := newFakeReader(ahead, behind)
+		mc := NewMultiCall(r, "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696").SetChunkLimits(10, 0)
+		balances := make([]*big.Int, 35)
+		for i := range balances {
+			mc.Register(&balances[i], "0x6B175474E89094C44Da98b954EedeAC495271d0F", eu.GetERC20ABI(), "balanceOf", common.BigToAddress(big.NewInt(int64(i))))
+		}
+		block, err := mc.Do(c.atBlock)
+		if err != nil {
+			t.Errorf("%s: Do failed: %s", c.name, err)
+			continue
+		}
+		if block != c.wantBlock {
+			t.Errorf("%s: Do read at block %d, want %d", c.name, block, c.wantBlock)
+		}
+		for i, b := range balances {
+			if b == nil || b.Int64() != int64(i) {
+				t.Errorf("%s: result %d is %v", c.name, i, b)
+				break
+			}
+		}
+		if ahead.calls == 0 || behind.calls == 0 {
+			t.Errorf("%s: chunks were not spread over the nodes: %d and %d calls", c.name, ahead.calls, behind.calls)
+		}
+	}
+}
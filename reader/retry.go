@@ -0,0 +1,27 @@
+package reader
+
+import (
+	"context"
+	"time"
+
+	eu "github.com/tranvictor/ethutils"
+)
+
+// SetRetryPolicy makes every request to a node be sent again according to
+// policy when it fails with a transient error, see eu.IsRetryableError.
+// The nodes are still queried at the same time so a read only fails once
+// every node has run out of attempts. A nil policy disables retries.
+func (self *EthReader) SetRetryPolicy(policy eu.RetryPolicy) {
+	self.retryPolicy = policy
+}
+
+// callNode runs call against n with the retry policy and records every
+// attempt in the health of n
+func (self *EthReader) callNode(ctx context.Context, n ContextEthereumNode, call func() error) error {
+	return eu.Retry(ctx, self.retryPolicy, func() error {
+		start := time.Now()
+		err := call()
+		self.recordNodeResult(n, start, err)
+		return err
+	})
+}
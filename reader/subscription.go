@@ -0,0 +1,366 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	eu "github.com/tranvictor/ethutils"
+)
+
+var ErrNoSubscriptionNode = fmt.Errorf("no node supports subscriptions, they need a ws://, wss:// or IPC url")
+
+// resubscribeBackoff is the wait between attempts to subscribe again
+// after the connection is lost
+var resubscribeBackoff = &eu.BackoffRetryPolicy{
+	InitialBackoff: time.Second,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// Subscription is a stream of events from the nodes. It keeps going over
+// reconnections until Unsubscribe is called or the context given to the
+// Subscribe method is done.
+type Subscription struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	errs   chan error
+}
+
+// Unsubscribe stops the subscription and waits until no more event is
+// delivered. It can be called more than once.
+func (self *Subscription) Unsubscribe() {
+	self.cancel()
+	<-self.done
+}
+
+// Err reports the errors the subscription recovered from, such as a lost
+// connection. Errors are dropped when they are not read. The channel is
+// closed when the subscription stops.
+func (self *Subscription) Err() <-chan error {
+	return self.errs
+}
+
+func (self *Subscription) report(err error) {
+	select {
+	case self.errs <- err:
+	default:
+	}
+}
+
+// subscriptionSpec describes one kind of subscription to run
+type subscriptionSpec struct {
+	// args are the eth_subscribe params
+	args []interface{}
+	// newChannel returns a channel of the notification type
+	newChannel func() interface{}
+	// deliver sends a notification to the caller, it returns false if ctx
+	// is done
+	deliver func(ctx context.Context, value interface{}) bool
+	// backfill delivers what was missed while disconnected, nil if it
+	// can't be done
+	backfill func(ctx context.Context) error
+}
+
+type subscriptionClient interface {
+	Client() (*rpc.Client, error)
+}
+
+// subscriptionNodes returns the healthy nodes that can serve
+// subscriptions, sorted by name
+func (self *EthReader) subscriptionNodes() []ContextEthereumNode {
+	result := []ContextEthereumNode{}
+	for _, n := range self.activeNodes() {
+		url := strings.ToLower(n.NodeURL())
+		if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+			continue
+		}
+		if _, ok := n.(subscriptionClient); ok {
+			result = append(result, n)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].NodeName() < result[j].NodeName() })
+	return result
+}
+
+// subscribeOnAnyNode subscribes on the first node that accepts, starting
+// from the node after the one used last time
+func (self *EthReader) subscribeOnAnyNode(ctx context.Context, spec *subscriptionSpec, next *int) (*rpc.ClientSubscription, reflect.Value, error) {
+	nodes := self.subscriptionNodes()
+	if len(nodes) == 0 {
+		return nil, reflect.Value{}, ErrNoSubscriptionNode
+	}
+	errs := []error{}
+	for i := 0; i < len(nodes); i++ {
+		n := nodes[(*next+i)%len(nodes)]
+		cli, err := n.(subscriptionClient).Client()
+		if err == nil {
+			channel := spec.newChannel()
+			timeout, cancel := withTimeout(ctx, TIMEOUT)
+			var sub *rpc.ClientSubscription
+			sub, err = cli.EthSubscribe(timeout, channel, spec.args...)
+			cancel()
+			if err == nil {
+				*next = (*next + i + 1) % len(nodes)
+				return sub, reflect.ValueOf(channel), nil
+			}
+		}
+		errs = append(errs, wrapError(err, n.NodeName()))
+	}
+	return nil, reflect.Value{}, fmt.Errorf("Couldn't subscribe on any nodes: %s", errorInfo(errs))
+}
+
+// subscribe makes the first subscription synchronously so a failure is
+// returned to the caller, then keeps the subscription alive in the
+// background
+func (self *EthReader) subscribe(ctx context.Context, spec *subscriptionSpec) (*Subscription, error) {
+	next := 0
+	sub, channel, err := self.subscribeOnAnyNode(ctx, spec, &next)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	result := &Subscription{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		errs:   make(chan error, 16),
+	}
+	go func() {
+		defer close(result.done)
+		defer close(result.errs)
+		for {
+			if !self.forward(ctx, spec, sub, channel, result) {
+				return
+			}
+			for attempt := 1; ; attempt++ {
+				timer := time.NewTimer(resubscribeBackoff.Backoff(attempt))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+				sub, channel, err = self.subscribeOnAnyNode(ctx, spec, &next)
+				if err == nil {
+					break
+				}
+				result.report(err)
+			}
+			if spec.backfill != nil {
+				if err := spec.backfill(ctx); err != nil {
+					result.report(fmt.Errorf("couldn't backfill the events missed while disconnected: %s", err))
+				}
+			}
+		}
+	}()
+	return result, nil
+}
+
+// forward delivers the notifications of sub until it fails, in which
+// case it returns true, or ctx is done
+func (self *EthReader) forward(ctx context.Context, spec *subscriptionSpec, sub *rpc.ClientSubscription, channel reflect.Value, result *Subscription) bool {
+	defer sub.Unsubscribe()
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(sub.Err())},
+		{Dir: reflect.SelectRecv, Chan: channel},
+	}
+	for {
+		chosen, value, _ := reflect.Select(cases)
+		switch chosen {
+		case 0:
+			return false
+		case 1:
+			err, _ := value.Interface().(error)
+			if err == nil {
+				err = fmt.Errorf("subscription closed")
+			}
+			result.report(fmt.Errorf("subscription lost, resubscribing: %s", err))
+			return true
+		default:
+			if !spec.deliver(ctx, value.Interface()) {
+				return false
+			}
+		}
+	}
+}
+
+// SubscribeNewHeads sends the header of every new block to ch. After a
+// reconnection, the headers of the blocks mined in between are sent
+// first.
+func (self *EthReader) SubscribeNewHeads(ctx context.Context, ch chan<- *types.Header) (*Subscription, error) {
+	// headers up to backfilled were sent by the backfill and are skipped
+	var last, backfilled uint64
+	if current, err := self.CurrentBlockContext(ctx); err == nil {
+		last = current
+	}
+	spec := &subscriptionSpec{
+		args:       []interface{}{"newHeads"},
+		newChannel: func() interface{} { return make(chan *types.Header) },
+	}
+	spec.deliver = func(ctx context.Context, value interface{}) bool {
+		header := value.(*types.Header)
+		number := header.Number.Uint64()
+		if number <= backfilled {
+			return true
+		}
+		if number > last {
+			last = number
+		}
+		select {
+		case ch <- header:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	spec.backfill = func(ctx context.Context) error {
+		if last == 0 {
+			return nil
+		}
+		current, err := self.CurrentBlockContext(ctx)
+		if err != nil {
+			return err
+		}
+		for number := last + 1; number <= current; number++ {
+			header, err := self.HeaderByNumberContext(ctx, int64(number))
+			if err != nil {
+				return err
+			}
+			select {
+			case ch <- header:
+			case <-ctx.Done():
+				return nil
+			}
+			last = number
+			backfilled = number
+		}
+		return nil
+	}
+	return self.subscribe(ctx, spec)
+}
+
+// logKey identifies a log across nodes and subscriptions
+type logKey struct {
+	blockHash common.Hash
+	index     uint
+}
+
+// SubscribeLogs sends the logs matching q to ch as they are mined, and the
+// logs removed by reorgs with Removed set. FromBlock and ToBlock of q are
+// ignored. After a reconnection, the logs mined in between are read with
+// FilterLogs and sent first. No log is sent twice.
+func (self *EthReader) SubscribeLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (*Subscription, error) {
+	arg := map[string]interface{}{}
+	if len(q.Addresses) > 0 {
+		arg["address"] = q.Addresses
+	}
+	if len(q.Topics) > 0 {
+		arg["topics"] = q.Topics
+	}
+	// next is the first block the backfill reads, 0 if unknown. It is
+	// the block of the last log received as the connection may drop
+	// before all the logs of that block are sent, so the backfill skips
+	// the logs in sent. The block of every log sent is kept there until
+	// logs of a block after backfilled arrive, as the new subscription
+	// can send again the logs of the blocks up to backfilled.
+	var next, backfilled uint64
+	sent := map[logKey]uint64{}
+	forget := func(below uint64) {
+		for key, number := range sent {
+			if number < below {
+				delete(sent, key)
+			}
+		}
+	}
+	if current, err := self.CurrentBlockContext(ctx); err == nil {
+		next = current + 1
+	}
+	spec := &subscriptionSpec{
+		args:       []interface{}{"logs", arg},
+		newChannel: func() interface{} { return make(chan types.Log) },
+	}
+	spec.deliver = func(ctx context.Context, value interface{}) bool {
+		l := value.(types.Log)
+		key := logKey{l.BlockHash, l.Index}
+		if l.Removed {
+			delete(sent, key)
+		} else {
+			if _, found := sent[key]; found {
+				return true
+			}
+			next = l.BlockNumber
+			if l.BlockNumber > backfilled {
+				forget(l.BlockNumber)
+			}
+			sent[key] = l.BlockNumber
+		}
+		select {
+		case ch <- l:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	spec.backfill = func(ctx context.Context) error {
+		current, err := self.CurrentBlockContext(ctx)
+		if err != nil {
+			return err
+		}
+		if next == 0 || current < next {
+			return nil
+		}
+		missed := q
+		missed.BlockHash = nil
+		missed.FromBlock = new(big.Int).SetUint64(next)
+		missed.ToBlock = new(big.Int).SetUint64(current)
+		logs, err := self.FilterLogsContext(ctx, missed)
+		if err != nil {
+			return err
+		}
+		forget(next)
+		for _, l := range logs {
+			key := logKey{l.BlockHash, l.Index}
+			if _, found := sent[key]; found {
+				continue
+			}
+			select {
+			case ch <- l:
+			case <-ctx.Done():
+				return nil
+			}
+			sent[key] = l.BlockNumber
+		}
+		next = current + 1
+		backfilled = current
+		return nil
+	}
+	return self.subscribe(ctx, spec)
+}
+
+// SubscribePendingTransactions sends the hash of every tx entering the
+// mempool of the node to ch. The txs seen while disconnected are lost.
+func (self *EthReader) SubscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (*Subscription, error) {
+	spec := &subscriptionSpec{
+		args:       []interface{}{"newPendingTransactions"},
+		newChannel: func() interface{} { return make(chan common.Hash) },
+	}
+	spec.deliver = func(ctx context.Context, value interface{}) bool {
+		select {
+		case ch <- value.(common.Hash):
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return self.subscribe(ctx, spec)
+}
@@ -0,0 +1,153 @@
+package reader
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	eu "github.com/tranvictor/ethutils"
+)
+
+// logsService serves eth_subscribe("logs"), every subscription sends the
+// logs written to the channel it hands to subscribed
+type logsService struct {
+	subscribed chan chan types.Log
+}
+
+func (self *logsService) Logs(ctx context.Context, crit map[string]interface{}) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	feed := make(chan types.Log)
+	go func() {
+		for {
+			select {
+			case l := <-feed:
+				notifier.Notify(sub.ID, l)
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	self.subscribed <- feed
+	return sub, nil
+}
+
+// subscriptionNode is a fake ws node, each Client call is a new
+// connection to the same logsService
+type subscriptionNode struct {
+	*fakeNode
+	server *rpc.Server
+	client atomic.Value
+}
+
+func newSubscriptionNode(name string, head uint64, service *logsService) *subscriptionNode {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		panic(err)
+	}
+	return &subscriptionNode{fakeNode: newFakeNode(name, head), server: server}
+}
+
+func (self *subscriptionNode) NodeURL() string {
+	return "ws://" + self.NodeName()
+}
+
+func (self *subscriptionNode) Client() (*rpc.Client, error) {
+	client := rpc.DialInProc(self.server)
+	self.client.Store(client)
+	return client, nil
+}
+
+// disconnect drops the current connection
+func (self *subscriptionNode) disconnect() {
+	self.client.Load().(*rpc.Client).Close()
+}
+
+func TestSubscribeLogsBackfillsAfterReconnect(t *testing.T) {
+	defer func(policy *eu.BackoffRetryPolicy) { resubscribeBackoff = policy }(resubscribeBackoff)
+	resubscribeBackoff = &eu.BackoffRetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1}
+
+	headers := map[int64]*types.Header{}
+	for number := int64(99); number <= 102; number++ {
+		headers[number] = &types.Header{Number: big.NewInt(number), Difficulty: big.NewInt(1)}
+	}
+	log := func(number int64, index uint) types.Log {
+		return types.Log{
+			Topics:      []common.Hash{},
+			Data:        []byte{},
+			BlockNumber: uint64(number),
+			BlockHash:   headers[number].Hash(),
+			Index:       index,
+		}
+	}
+	chain := []types.Log{log(99, 0), log(100, 0), log(100, 1), log(101, 0), log(102, 0)}
+
+	service := &logsService{subscribed: make(chan chan types.Log, 4)}
+	n := newSubscriptionNode("node", 100, service)
+	n.header = func(number int64) (*types.Header, error) { return headers[number], nil }
+	queried := [][2]uint64{}
+	n.logs = func(q ethereum.FilterQuery) ([]types.Log, error) {
+		queried = append(queried, [2]uint64{q.FromBlock.Uint64(), q.ToBlock.Uint64()})
+		result := []types.Log{}
+		for _, l := range chain {
+			if q.FromBlock.Uint64() <= l.BlockNumber && l.BlockNumber <= q.ToBlock.Uint64() {
+				result = append(result, l)
+			}
+		}
+		return result, nil
+	}
+	r := newFakeReader(n)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ch := make(chan types.Log)
+	sub, err := r.SubscribeLogs(ctx, ethereum.FilterQuery{}, ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	receive := func() types.Log {
+		select {
+		case l := <-ch:
+			return l
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for a log")
+		}
+		return types.Log{}
+	}
+	expect := func(want types.Log) {
+		if got := receive(); got.BlockHash != want.BlockHash || got.Index != want.Index {
+			t.Fatalf("got log %d/%d, want %d/%d", got.BlockNumber, got.Index, want.BlockNumber, want.Index)
+		}
+	}
+
+	// the connection drops after the first log of block 100 and block
+	// 101 is mined meanwhile
+	feed := <-service.subscribed
+	feed <- log(100, 0)
+	expect(log(100, 0))
+	atomic.StoreUint64(&n.head, 101)
+	n.disconnect()
+
+	// the new subscription repeats block 101 before block 102
+	feed = <-service.subscribed
+	expect(log(100, 1))
+	expect(log(101, 0))
+	feed <- log(101, 0)
+	feed <- log(102, 0)
+	expect(log(102, 0))
+
+	if len(queried) != 1 || queried[0] != [2]uint64{100, 101} {
+		t.Errorf("backfill read %v, want [[100 101]]", queried)
+	}
+}
@@ -0,0 +1,194 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eu "github.com/tranvictor/ethutils"
+)
+
+// ErrNoTraceCapableNode is returned when tracing is requested but none
+// of the nodes of the reader is marked as trace capable
+var ErrNoTraceCapableNode = errors.New("no trace capable node")
+
+// CallFrame is one call of the tree returned by the callTracer of
+// debug_traceTransaction
+type CallFrame struct {
+	Type    string          `json:"type"`
+	From    common.Address  `json:"from"`
+	To      common.Address  `json:"to"`
+	Value   *hexutil.Big    `json:"value,omitempty"`
+	Gas     *hexutil.Uint64 `json:"gas,omitempty"`
+	GasUsed *hexutil.Uint64 `json:"gasUsed,omitempty"`
+	Input   hexutil.Bytes   `json:"input,omitempty"`
+	Output  hexutil.Bytes   `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Calls   []CallFrame     `json:"calls,omitempty"`
+}
+
+// InternalTxs flattens the call tree into the value bearing internal
+// calls. The root frame is the tx itself so it is not included. Frames
+// that failed are skipped together with their sub calls because their
+// value transfers were reverted. DELEGATECALL and CALLCODE frames run
+// code in the context of the caller so the value they carry never moves,
+// and STATICCALL frames can't move value, so they are not reported but
+// their sub calls are.
+func (self *CallFrame) InternalTxs() []eu.InternalTx {
+	result := []eu.InternalTx{}
+	if self.Error != "" {
+		return result
+	}
+	for i := range self.Calls {
+		self.Calls[i].collectInternalTxs(1, &result)
+	}
+	return result
+}
+
+// movesValue returns true if the frame transfers ETH to another account
+func (self *CallFrame) movesValue() bool {
+	switch strings.ToUpper(self.Type) {
+	case "DELEGATECALL", "CALLCODE", "STATICCALL":
+		return false
+	}
+	return self.Value != nil && (*big.Int)(self.Value).Sign() > 0
+}
+
+func (self *CallFrame) collectInternalTxs(depth int, result *[]eu.InternalTx) {
+	if self.Error != "" {
+		return
+	}
+	if self.movesValue() {
+		*result = append(*result, eu.InternalTx{
+			From:  self.From.Hex(),
+			To:    self.To.Hex(),
+			Value: (*big.Int)(self.Value).String(),
+			Type:  strings.ToUpper(self.Type),
+			Depth: depth,
+		})
+	}
+	for i := range self.Calls {
+		self.Calls[i].collectInternalTxs(depth+1, result)
+	}
+}
+
+// SetTraceCapableNodes marks the nodes with the given names as trace
+// capable so they are used by TraceTransaction and InternalTxsFromHash
+func (self *EthReader) SetTraceCapableNodes(names ...string) error {
+	for _, name := range names {
+		n, found := self.nodes[name]
+		if !found {
+			return fmt.Errorf("node %s is not found", name)
+		}
+		setter, ok := n.(interface{ SetTraceCapable(bool) })
+		if !ok {
+			return fmt.Errorf("node %s doesn't support tracing", name)
+		}
+		setter.SetTraceCapable(true)
+	}
+	return nil
+}
+
+// traceCapableNodes prefers the healthy trace capable nodes and falls
+// back to every trace capable node when none of them is healthy
+func (self *EthReader) traceCapableNodes() []ContextEthereumNode {
+	result := []ContextEthereumNode{}
+	for _, n := range self.activeNodes() {
+		if n.IsTraceCapable() {
+			result = append(result, n)
+		}
+	}
+	if len(result) > 0 {
+		return result
+	}
+	for _, n := range self.nodes {
+		if n.IsTraceCapable() {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+type traceTransactionResponse struct {
+	Frame *CallFrame
+	Error error
+}
+
+// TraceTransaction returns the call tree of a mined tx from the first
+// trace capable node that answers. It returns ErrNoTraceCapableNode if
+// no node is trace capable.
+func (self *EthReader) TraceTransaction(txHash string) (*CallFrame, error) {
+	return self.TraceTransactionContext(context.Background(), txHash)
+}
+
+func (self *EthReader) TraceTransactionContext(ctx context.Context, txHash string) (*CallFrame, error) {
+	nodes := self.traceCapableNodes()
+	if len(nodes) == 0 {
+		return nil, ErrNoTraceCapableNode
+	}
+	resCh := make(chan traceTransactionResponse, len(nodes))
+	for i, _ := range nodes {
+		n := nodes[i]
+		go func() {
+			var frame *CallFrame
+			err := self.callNode(ctx, n, func() (err error) {
+				frame, err = n.TraceTransactionContext(ctx, txHash)
+				return err
+			})
+			resCh <- traceTransactionResponse{
+				Frame: frame,
+				Error: wrapError(err, n.NodeName()),
+			}
+		}()
+	}
+	errs := []error{}
+	for i := 0; i < len(nodes); i++ {
+		result := <-resCh
+		if result.Error == nil {
+			return result.Frame, result.Error
+		}
+		errs = append(errs, result.Error)
+	}
+	return nil, fmt.Errorf("Couldn't read from any nodes: %s", errorInfo(errs))
+}
+
+// InternalTxsFromHash returns every value bearing internal call of a
+// mined tx
+func (self *EthReader) InternalTxsFromHash(txHash string) ([]eu.InternalTx, error) {
+	return self.InternalTxsFromHashContext(context.Background(), txHash)
+}
+
+func (self *EthReader) InternalTxsFromHashContext(ctx context.Context, txHash string) ([]eu.InternalTx, error) {
+	frame, err := self.TraceTransactionContext(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	return frame.InternalTxs(), nil
+}
+
+// TxInfoWithInternalTxsFromHash works as TxInfoFromHash and also fills
+// InternalTxs of mined txs from the trace capable nodes. If no node is
+// trace capable, InternalTxs is left empty and no error is returned.
+func (self *EthReader) TxInfoWithInternalTxsFromHash(tx string) (eu.TxInfo, error) {
+	return self.TxInfoWithInternalTxsFromHashContext(context.Background(), tx)
+}
+
+func (self *EthReader) TxInfoWithInternalTxsFromHashContext(ctx context.Context, tx string) (eu.TxInfo, error) {
+	info, err := self.TxInfoFromHashContext(ctx, tx)
+	if err != nil || !info.Status.IsMined() {
+		return info, err
+	}
+	internalTxs, err := self.InternalTxsFromHashContext(ctx, tx)
+	if err == ErrNoTraceCapableNode {
+		return info, nil
+	}
+	if err != nil {
+		return info, fmt.Errorf("tracing internal txs failed: %w", err)
+	}
+	info.InternalTxs = internalTxs
+	return info, nil
+}
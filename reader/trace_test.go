@@ -0,0 +1,142 @@
+package reader
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	eu "github.com/tranvictor/ethutils"
+)
+
+// a callTracer result where a proxy delegates to its implementation which
+// pays two accounts, one of the payments being reverted, and a static
+// call and a callcode carry value that never moves
+const traceFixture = `{
+  "type": "CALL",
+  "from": "0x00000000000000000000000000000000000000e0",
+  "to": "0x0000000000000000000000000000000000000001",
+  "value": "0xde0b6b3a7640000",
+  "calls": [
+    {
+      "type": "DELEGATECALL",
+      "from": "0x0000000000000000000000000000000000000001",
+      "to": "0x0000000000000000000000000000000000000002",
+      "value": "0xde0b6b3a7640000",
+      "calls": [
+        {
+          "type": "CALL",
+          "from": "0x0000000000000000000000000000000000000001",
+          "to": "0x00000000000000000000000000000000000000a1",
+          "value": "0x3e8",
+          "calls": [
+            {
+              "type": "CALL",
+              "from": "0x00000000000000000000000000000000000000a1",
+              "to": "0x00000000000000000000000000000000000000a2",
+              "value": "0x1"
+            }
+          ]
+        },
+        {
+          "type": "CALL",
+          "from": "0x0000000000000000000000000000000000000001",
+          "to": "0x00000000000000000000000000000000000000b1",
+          "value": "0x7d0",
+          "error": "execution reverted",
+          "calls": [
+            {
+              "type": "CALL",
+              "from": "0x00000000000000000000000000000000000000b1",
+              "to": "0x00000000000000000000000000000000000000b2",
+              "value": "0x2"
+            }
+          ]
+        },
+        {
+          "type": "STATICCALL",
+          "from": "0x0000000000000000000000000000000000000001",
+          "to": "0x0000000000000000000000000000000000000003",
+          "value": "0x5"
+        },
+        {
+          "type": "CALLCODE",
+          "from": "0x0000000000000000000000000000000000000001",
+          "to": "0x0000000000000000000000000000000000000004",
+          "value": "0x6",
+          "calls": [
+            {
+              "type": "CREATE",
+              "from": "0x0000000000000000000000000000000000000001",
+              "to": "0x00000000000000000000000000000000000000c1",
+              "value": "0x7"
+            }
+          ]
+        },
+        {
+          "type": "CALL",
+          "from": "0x0000000000000000000000000000000000000001",
+          "to": "0x00000000000000000000000000000000000000d1",
+          "value": "0x0"
+        },
+        {
+          "type": "SELFDESTRUCT",
+          "from": "0x0000000000000000000000000000000000000001",
+          "to": "0x00000000000000000000000000000000000000e1",
+          "value": "0x8"
+        }
+      ]
+    }
+  ]
+}`
+
+func TestCallFrameInternalTxs(t *testing.T) {
+	frame := CallFrame{}
+	if err := json.Unmarshal([]byte(traceFixture), &frame); err != nil {
+		t.Fatal(err)
+	}
+	internalTx := func(from, to, value, typ string, depth int) eu.InternalTx {
+		return eu.InternalTx{
+			From:  common.HexToAddress(from).Hex(),
+			To:    common.HexToAddress(to).Hex(),
+			Value: value,
+			Type:  typ,
+			Depth: depth,
+		}
+	}
+	want := []eu.InternalTx{
+		internalTx("0x01", "0xa1", "1000", "CALL", 2),
+		internalTx("0xa1", "0xa2", "1", "CALL", 3),
+		internalTx("0x01", "0xc1", "7", "CREATE", 3),
+		internalTx("0x01", "0xe1", "8", "SELFDESTRUCT", 2),
+	}
+	if got := frame.InternalTxs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	frame.Error = "out of gas"
+	if got := frame.InternalTxs(); len(got) != 0 {
+		t.Errorf("failed tx has internal txs %+v", got)
+	}
+}
+
+func TestSetTraceCapableConcurrently(t *testing.T) {
+	n := NewOneNodeReader("node", "http://127.0.0.1:1")
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			n.SetTraceCapable(true)
+		}()
+		go func() {
+			defer wg.Done()
+			n.IsTraceCapable()
+		}()
+	}
+	wg.Wait()
+	if !n.IsTraceCapable() {
+		t.Errorf("node is not trace capable")
+	}
+}
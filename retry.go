@@ -0,0 +1,175 @@
+package ethutils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RetryPolicy decides whether a failed request is sent again. attempt is
+// the number of attempts done so far, starting from 1. It returns how
+// long to wait before the next attempt and false to give up.
+type RetryPolicy interface {
+	Retry(attempt int, err error) (wait time.Duration, retry bool)
+}
+
+// BackoffRetryPolicy retries retryable errors up to MaxAttempts attempts
+// in total, waiting InitialBackoff * Multiplier^(attempt-1) capped at
+// MaxBackoff between attempts. Jitter randomizes each wait by up to that
+// fraction of it so clients don't retry in lockstep.
+type BackoffRetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+	// IsRetryable classifies the errors, IsRetryableError is used if it
+	// is nil
+	IsRetryable func(err error) bool
+}
+
+func DefaultRetryPolicy() *BackoffRetryPolicy {
+	return &BackoffRetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// NoRetry sends every request exactly once
+func NoRetry() *BackoffRetryPolicy {
+	return &BackoffRetryPolicy{MaxAttempts: 1}
+}
+
+func (self *BackoffRetryPolicy) Retry(attempt int, err error) (time.Duration, bool) {
+	if attempt >= self.MaxAttempts {
+		return 0, false
+	}
+	isRetryable := self.IsRetryable
+	if isRetryable == nil {
+		isRetryable = IsRetryableError
+	}
+	if !isRetryable(err) {
+		return 0, false
+	}
+	return self.Backoff(attempt), true
+}
+
+// Backoff returns the wait after the attempt-th failed attempt
+func (self *BackoffRetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := self.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	wait := float64(self.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if self.MaxBackoff > 0 && wait > float64(self.MaxBackoff) {
+		wait = float64(self.MaxBackoff)
+	}
+	if self.Jitter > 0 {
+		wait += wait * self.Jitter * (2*rand.Float64() - 1)
+	}
+	if wait < 0 {
+		return 0
+	}
+	return time.Duration(wait)
+}
+
+// Retry runs op until it succeeds, policy gives up or ctx is done. It
+// returns the error of the last attempt. A nil policy runs op once.
+func Retry(ctx context.Context, policy RetryPolicy, op func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || policy == nil {
+			return err
+		}
+		wait, retry := policy.Retry(attempt, err)
+		if !retry || ctx.Err() != nil {
+			return err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// error codes some providers use to reject requests over their rate limit
+const (
+	RPC_LIMIT_EXCEEDED_CODE int = -32005
+	RPC_RATE_LIMITED_CODE   int = 429
+)
+
+// messages of transient errors that don't come with a usable type
+var transientErrorMessages = []string{
+	"too many requests",
+	"rate limit",
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"timeout",
+	"timed out",
+	"temporarily unavailable",
+	"service unavailable",
+	"bad gateway",
+	"header not found",
+}
+
+// IsRetryableError returns true for errors that can go away by sending
+// the request again, such as timeouts, HTTP 429 and 5xx responses and
+// connection resets. Errors returned by a node that handled the request,
+// such as execution reverted, are permanent.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests ||
+			httpErr.StatusCode == http.StatusRequestTimeout ||
+			httpErr.StatusCode >= 500
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		code := rpcErr.ErrorCode()
+		if code == RPC_LIMIT_EXCEEDED_CODE || code == RPC_RATE_LIMITED_CODE {
+			return true
+		}
+	}
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "revert") {
+		return false
+	}
+	for _, m := range transientErrorMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package ethutils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type codeError struct {
+	code int
+	msg  string
+}
+
+func (self codeError) Error() string  { return self.msg }
+func (self codeError) ErrorCode() int { return self.code }
+
+func TestIsRetryableError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"}, true},
+		{rpc.HTTPError{StatusCode: 408, Status: "408 Request Timeout"}, true},
+		{rpc.HTTPError{StatusCode: 500, Status: "500 Internal Server Error"}, true},
+		{rpc.HTTPError{StatusCode: 502, Status: "502 Bad Gateway"}, true},
+		{rpc.HTTPError{StatusCode: 503, Status: "503 Service Unavailable"}, true},
+		{rpc.HTTPError{StatusCode: 401, Status: "401 Unauthorized"}, false},
+		{rpc.HTTPError{StatusCode: 404, Status: "404 Not Found"}, false},
+		{fmt.Errorf("calling: %w", rpc.HTTPError{StatusCode: 503}), true},
+		{codeError{RPC_LIMIT_EXCEEDED_CODE, "limit exceeded"}, true},
+		{codeError{RPC_RATE_LIMITED_CODE, "slow down"}, true},
+		{codeError{3, "execution reverted: not owner"}, false},
+		{codeError{-32000, "execution reverted"}, false},
+		{codeError{-32000, "insufficient funds for gas * price + value"}, false},
+		{codeError{-32000, "header not found"}, true},
+		{fmt.Errorf("execution reverted, request timed out"), false},
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("reading: %w", context.DeadlineExceeded), true},
+		{context.Canceled, false},
+		{fmt.Errorf("reading: %w", context.Canceled), false},
+		{ethereum.NotFound, false},
+		{io.EOF, true},
+		{syscall.ECONNRESET, true},
+		{syscall.ECONNREFUSED, true},
+		{fmt.Errorf("Post \"https://node\": dial tcp: i/o timeout"), true},
+		{fmt.Errorf("invalid argument 0: hex string has odd length"), false},
+	}
+	for _, c := range cases {
+		if got := IsRetryableError(c.err); got != c.want {
+			t.Errorf("IsRetryableError(%v) = %t, want %t", c.err, got, c.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := &BackoffRetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     3,
+	}
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 300 * time.Millisecond},
+		{3, 900 * time.Millisecond},
+		{4, time.Second},
+		{10, time.Second},
+	}
+	for _, c := range cases {
+		if got := policy.Backoff(c.attempt); got != c.want {
+			t.Errorf("Backoff(%d) = %s, want %s", c.attempt, got, c.want)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := policy.Backoff(2); got < 150*time.Millisecond || got > 450*time.Millisecond {
+			t.Fatalf("Backoff(2) with jitter = %s, want within 150ms and 450ms", got)
+		}
+	}
+}
+
+func TestBackoffRetryPolicy(t *testing.T) {
+	policy := &BackoffRetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	transient := codeError{RPC_LIMIT_EXCEEDED_CODE, "limit exceeded"}
+	cases := []struct {
+		attempt int
+		err     error
+		retry   bool
+	}{
+		{1, transient, true},
+		{2, transient, true},
+		{3, transient, false},
+		{1, codeError{3, "execution reverted"}, false},
+		{1, context.Canceled, false},
+	}
+	for _, c := range cases {
+		if _, retry := policy.Retry(c.attempt, c.err); retry != c.retry {
+			t.Errorf("Retry(%d, %v) = %t, want %t", c.attempt, c.err, retry, c.retry)
+		}
+	}
+
+	attempts := 0
+	err := Retry(context.Background(), policy, func() error {
+		attempts += 1
+		return transient
+	})
+	if err != transient || attempts != 3 {
+		t.Errorf("Retry gave %v after %d attempts, want the last error after 3", err, attempts)
+	}
+}
@@ -0,0 +1,67 @@
+package ethutils
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// selector of Error(string), the error of require and revert with a
+	// message
+	REVERT_ERROR_SELECTOR = crypto.Keccak256([]byte("Error(string)"))[:4]
+	// selector of Panic(uint256), the error of failed asserts, overflows,
+	// divisions by zero... since solidity 0.8
+	REVERT_PANIC_SELECTOR = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// reasons of the solidity panic codes
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to an uninitialized internal function",
+}
+
+// DecodeRevertReason decodes the return data of a reverted call. It
+// returns the message of Error(string), a description of Panic(uint256)
+// and the selector of custom errors. Empty data gives an empty reason.
+func DecodeRevertReason(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	if len(data) < 4 {
+		return "", fmt.Errorf("revert data is too short: %s", common.Bytes2Hex(data))
+	}
+	selector, args := data[:4], data[4:]
+	switch {
+	case bytes.Equal(selector, REVERT_ERROR_SELECTOR):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return "", fmt.Errorf("couldn't decode the revert message: %s", err)
+		}
+		return reason, nil
+	case bytes.Equal(selector, REVERT_PANIC_SELECTOR):
+		if len(args) != 32 {
+			return "", fmt.Errorf("couldn't decode the panic code: %s", common.Bytes2Hex(args))
+		}
+		code := new(big.Int).SetBytes(args)
+		reason, found := panicReasons[code.Uint64()]
+		if !found || !code.IsUint64() {
+			reason = "unknown panic"
+		}
+		return fmt.Sprintf("panic 0x%x: %s", code, reason), nil
+	default:
+		return fmt.Sprintf("custom error 0x%s", common.Bytes2Hex(selector)), nil
+	}
+}
@@ -0,0 +1,73 @@
+package ethutils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func revertData(t *testing.T, selector []byte, typ string, value interface{}) []byte {
+	t.Helper()
+	argType, err := abi.NewType(typ, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, err := abi.Arguments{{Type: argType}}.Pack(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(append([]byte{}, selector...), args...)
+}
+
+func TestDecodeRevertReason(t *testing.T) {
+	hugeCode := new(big.Int).Lsh(big.NewInt(1), 100)
+	customError := crypto.Keccak256([]byte("InsufficientBalance(uint256,uint256)"))[:4]
+	cases := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"empty", nil, ""},
+		{"error", revertData(t, REVERT_ERROR_SELECTOR, "string", "Ownable: caller is not the owner"), "Ownable: caller is not the owner"},
+		{"empty error message", revertData(t, REVERT_ERROR_SELECTOR, "string", ""), ""},
+		{"overflow panic", revertData(t, REVERT_PANIC_SELECTOR, "uint256", big.NewInt(0x11)), "panic 0x11: arithmetic overflow or underflow"},
+		{"division panic", revertData(t, REVERT_PANIC_SELECTOR, "uint256", big.NewInt(0x12)), "panic 0x12: division or modulo by zero"},
+		{"assert panic", revertData(t, REVERT_PANIC_SELECTOR, "uint256", big.NewInt(0x01)), "panic 0x1: assert failed"},
+		{"unknown panic", revertData(t, REVERT_PANIC_SELECTOR, "uint256", big.NewInt(0x99)), "panic 0x99: unknown panic"},
+		{"panic code over uint64", append(append([]byte{}, REVERT_PANIC_SELECTOR...), math.U256Bytes(hugeCode)...), "panic 0x10000000000000000000000000: unknown panic"},
+		{"custom error", revertData(t, customError, "uint256", big.NewInt(1)), "custom error 0x" + common.Bytes2Hex(customError)},
+		{"custom error without args", customError, "custom error 0x" + common.Bytes2Hex(customError)},
+	}
+	for _, c := range cases {
+		got, err := DecodeRevertReason(c.data)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.name, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("%s: DecodeRevertReason = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestDecodeRevertReasonInvalid(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"shorter than a selector", []byte{0x08, 0xc3, 0x79}},
+		{"error without message", REVERT_ERROR_SELECTOR},
+		{"truncated error message", revertData(t, REVERT_ERROR_SELECTOR, "string", "a long enough message")[:40]},
+		{"panic without code", REVERT_PANIC_SELECTOR},
+		{"panic with a short code", append(append([]byte{}, REVERT_PANIC_SELECTOR...), 0x11)},
+	}
+	for _, c := range cases {
+		if got, err := DecodeRevertReason(c.data); err == nil {
+			t.Errorf("%s: expected an error, got %q", c.name, got)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package ethutils
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -10,11 +11,47 @@ import (
 )
 
 // RawTxToHash returns valid hex data of a transaction to
-// transaction hash
+// transaction hash. It panics if data is not valid hex.
+//
+// Deprecated: use ParseRawTxHash which returns an error instead.
 func RawTxToHash(data string) string {
 	return crypto.Keccak256Hash(hexutil.MustDecode(data)).Hex()
 }
 
+// DecodeRawTx decodes hex data of a signed legacy or typed transaction
+// and recovers its sender into Extra.From. The chain ID the tx is signed
+// for is available through ChainId() and is 0 for legacy txs signed
+// without EIP-155 replay protection.
+func DecodeRawTx(data string) (*Transaction, error) {
+	raw, err := hexutil.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw tx hex: %w", err)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("malformed raw tx: %w", err)
+	}
+	if _, r, s := tx.RawSignatureValues(); r == nil || s == nil || r.Sign() == 0 || s.Sign() == 0 {
+		return nil, fmt.Errorf("raw tx is not signed")
+	}
+	var signer types.Signer
+	if tx.Type() == types.LegacyTxType && !tx.Protected() {
+		signer = types.HomesteadSigner{}
+	} else {
+		signer = types.LatestSignerForChainID(tx.ChainId())
+	}
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't recover sender of the raw tx: %w", err)
+	}
+	return &Transaction{
+		Transaction: tx,
+		Extra: TxExtraInfo{
+			From: &from,
+		},
+	}, nil
+}
+
 func BuildExactTx(nonce uint64, to string, ethAmount *big.Int, gasLimit uint64, priceGwei float64, data []byte) (tx *types.Transaction) {
 	toAddress := common.HexToAddress(to)
 	gasPrice := GweiToWei(priceGwei)
@@ -38,3 +75,127 @@ func BuildContractCreationTx(nonce uint64, ethAmount *big.Int, gasLimit uint64,
 	gasPrice := GweiToWei(priceGwei)
 	return types.NewContractCreation(nonce, ethAmount, gasLimit, gasPrice, data)
 }
+
+// BuildExactDynamicFeeTx builds an EIP-1559 (type 2) transaction.
+// tipCapGwei is the max priority fee per gas and feeCapGwei is the
+// max fee per gas, both in gwei.
+func BuildExactDynamicFeeTx(chainID int64, nonce uint64, to string, ethAmount *big.Int, gasLimit uint64, tipCapGwei, feeCapGwei float64, data []byte) (tx *types.Transaction) {
+	return BuildExactDynamicFeeTxWei(chainID, nonce, to, ethAmount, gasLimit, GweiToWei(tipCapGwei), GweiToWei(feeCapGwei), data)
+}
+
+// BuildExactDynamicFeeTxWei is BuildExactDynamicFeeTx with the fees in
+// wei so they are used as is.
+func BuildExactDynamicFeeTxWei(chainID int64, nonce uint64, to string, ethAmount *big.Int, gasLimit uint64, tipCap, feeCap *big.Int, data []byte) (tx *types.Transaction) {
+	toAddress := common.HexToAddress(to)
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(chainID),
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gasLimit,
+		To:        &toAddress,
+		Value:     ethAmount,
+		Data:      data,
+	})
+}
+
+func BuildDynamicFeeTx(chainID int64, nonce uint64, to string, ethAmount float64, gasLimit uint64, tipCapGwei, feeCapGwei float64, data []byte) (tx *types.Transaction) {
+	amount := FloatToBigInt(ethAmount, 18)
+	return BuildExactDynamicFeeTx(chainID, nonce, to, amount, gasLimit, tipCapGwei, feeCapGwei, data)
+}
+
+func BuildDynamicFeeSendETHTx(chainID int64, nonce uint64, to string, ethAmount float64, tipCapGwei, feeCapGwei float64) (tx *types.Transaction) {
+	return BuildDynamicFeeTx(chainID, nonce, to, ethAmount, 30000, tipCapGwei, feeCapGwei, []byte{})
+}
+
+func BuildExactDynamicFeeSendETHTx(chainID int64, nonce uint64, to string, ethAmount *big.Int, gasLimit uint64, tipCapGwei, feeCapGwei float64) (tx *types.Transaction) {
+	return BuildExactDynamicFeeTx(chainID, nonce, to, ethAmount, gasLimit, tipCapGwei, feeCapGwei, []byte{})
+}
+
+func BuildDynamicFeeContractCreationTx(chainID int64, nonce uint64, ethAmount *big.Int, gasLimit uint64, tipCapGwei, feeCapGwei float64, data []byte) (tx *types.Transaction) {
+	return BuildDynamicFeeContractCreationTxWei(chainID, nonce, ethAmount, gasLimit, GweiToWei(tipCapGwei), GweiToWei(feeCapGwei), data)
+}
+
+// BuildDynamicFeeContractCreationTxWei is
+// BuildDynamicFeeContractCreationTx with the fees in wei so they are
+// used as is.
+func BuildDynamicFeeContractCreationTxWei(chainID int64, nonce uint64, ethAmount *big.Int, gasLimit uint64, tipCap, feeCap *big.Int, data []byte) (tx *types.Transaction) {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(chainID),
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gasLimit,
+		To:        nil,
+		Value:     ethAmount,
+		Data:      data,
+	})
+}
+
+// BuildExactAccessListTx builds an EIP-2930 (type 1) transaction that
+// carries the given access list.
+func BuildExactAccessListTx(chainID int64, nonce uint64, to string, ethAmount *big.Int, gasLimit uint64, priceGwei float64, data []byte, accessList types.AccessList) (tx *types.Transaction) {
+	toAddress := common.HexToAddress(to)
+	return types.NewTx(&types.AccessListTx{
+		ChainID:    big.NewInt(chainID),
+		Nonce:      nonce,
+		GasPrice:   GweiToWei(priceGwei),
+		Gas:        gasLimit,
+		To:         &toAddress,
+		Value:      ethAmount,
+		Data:       data,
+		AccessList: accessList,
+	})
+}
+
+func BuildAccessListTx(chainID int64, nonce uint64, to string, ethAmount float64, gasLimit uint64, priceGwei float64, data []byte, accessList types.AccessList) (tx *types.Transaction) {
+	amount := FloatToBigInt(ethAmount, 18)
+	return BuildExactAccessListTx(chainID, nonce, to, amount, gasLimit, priceGwei, data, accessList)
+}
+
+func BuildAccessListContractCreationTx(chainID int64, nonce uint64, ethAmount *big.Int, gasLimit uint64, priceGwei float64, data []byte, accessList types.AccessList) (tx *types.Transaction) {
+	return types.NewTx(&types.AccessListTx{
+		ChainID:    big.NewInt(chainID),
+		Nonce:      nonce,
+		GasPrice:   GweiToWei(priceGwei),
+		Gas:        gasLimit,
+		To:         nil,
+		Value:      ethAmount,
+		Data:       data,
+		AccessList: accessList,
+	})
+}
+
+// WithAccessList returns a copy of an unsigned tx with the access list
+// attached. Legacy txs are turned into access list (type 1) txs while
+// dynamic fee txs keep their type. gasLimit replaces the gas limit of
+// the original tx when it is not 0 since the access list changes the
+// intrinsic gas of the tx.
+func WithAccessList(chainID int64, tx *types.Transaction, accessList types.AccessList, gasLimit uint64) *types.Transaction {
+	if gasLimit == 0 {
+		gasLimit = tx.Gas()
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    big.NewInt(chainID),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Gas:        gasLimit,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: accessList,
+		})
+	}
+	return types.NewTx(&types.AccessListTx{
+		ChainID:    big.NewInt(chainID),
+		Nonce:      tx.Nonce(),
+		GasPrice:   tx.GasPrice(),
+		Gas:        gasLimit,
+		To:         tx.To(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: accessList,
+	})
+}
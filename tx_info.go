@@ -9,27 +9,49 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// InternalTx is a value transfer made by a contract during the execution
+// of a tx. Value is in wei as a decimal string, Type is the call type
+// (CALL, CREATE, SELFDESTRUCT...) and Depth is the call depth where 1 is
+// a call made directly by the contract the tx called.
 type InternalTx struct {
 	From  string `json:"from"`
 	To    string `json:"to"`
 	Value string `json:"value"`
+	Type  string `json:"type"`
+	Depth int    `json:"depth"`
 }
 
+// TxInfo is the outcome of a tx as read from the nodes. Its JSON form
+// is stable and can be stored and unmarshaled back to a TxInfo.
 type TxInfo struct {
-	Status      string
-	Tx          *Transaction
-	InternalTxs []InternalTx
-	Receipt     *types.Receipt
-	BlockHeader *types.Header
+	Status      TxStatus       `json:"status"`
+	Tx          *Transaction   `json:"tx"`
+	InternalTxs []InternalTx   `json:"internalTxs"`
+	Receipt     *types.Receipt `json:"receipt"`
+	BlockHeader *types.Header  `json:"blockHeader"`
 }
 
+// GasCost returns the fee paid by the tx in wei. For dynamic fee txs
+// the effective gas price is derived from the base fee of the block
+// header so the header must be available.
 func (self *TxInfo) GasCost() *big.Int {
 	return big.NewInt(0).Mul(
 		big.NewInt(int64(self.Receipt.GasUsed)),
-		self.Tx.GasPrice(),
+		self.EffectiveGasPrice(),
 	)
 }
 
+// EffectiveGasPrice returns the gas price the tx actually paid per gas.
+// It is the gas price for legacy txs and base fee + effective tip for
+// dynamic fee txs.
+func (self *TxInfo) EffectiveGasPrice() *big.Int {
+	if self.BlockHeader == nil || self.BlockHeader.BaseFee == nil {
+		return self.Tx.GasPrice()
+	}
+	baseFee := self.BlockHeader.BaseFee
+	return big.NewInt(0).Add(baseFee, self.Tx.EffectiveGasTipValue(baseFee))
+}
+
 type Transaction struct {
 	*types.Transaction
 	Extra TxExtraInfo `json:"extra"`
@@ -48,16 +70,24 @@ func (tx *Transaction) UnmarshalJSON(msg []byte) error {
 	return json.Unmarshal(msg, &tx.Extra)
 }
 
+// txmarshaling mirrors the JSON-RPC representation of a tx of any type
+// together with the block and sender fields so the output can be read
+// back by UnmarshalJSON.
 type txmarshaling struct {
-	AccountNonce hexutil.Uint64  `json:"nonce"`
-	Price        *hexutil.Big    `json:"gasPrice"`
-	GasLimit     hexutil.Uint64  `json:"gas"`
-	Recipient    *common.Address `json:"to"`
-	Amount       *hexutil.Big    `json:"value"`
-	Payload      hexutil.Bytes   `json:"input"`
-	BlockNumber  *string         `json:"blockNumber,omitempty"`
-	BlockHash    *common.Hash    `json:"blockHash,omitempty"`
-	From         *common.Address `json:"from,omitempty"`
+	Type                 hexutil.Uint64    `json:"type"`
+	ChainID              *hexutil.Big      `json:"chainId,omitempty"`
+	AccountNonce         hexutil.Uint64    `json:"nonce"`
+	Price                *hexutil.Big      `json:"gasPrice,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	GasLimit             hexutil.Uint64    `json:"gas"`
+	Recipient            *common.Address   `json:"to"`
+	Amount               *hexutil.Big      `json:"value"`
+	Payload              hexutil.Bytes     `json:"input"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+	BlockNumber          *string           `json:"blockNumber,omitempty"`
+	BlockHash            *common.Hash      `json:"blockHash,omitempty"`
+	From                 *common.Address   `json:"from,omitempty"`
 
 	// Signature values
 	V *hexutil.Big `json:"v"`
@@ -72,8 +102,8 @@ func (tx *Transaction) MarshalJSON() ([]byte, error) {
 	v, r, s := tx.Transaction.RawSignatureValues()
 	h := tx.Transaction.Hash()
 	txmar := txmarshaling{
+		Type:         hexutil.Uint64(tx.Transaction.Type()),
 		AccountNonce: hexutil.Uint64(tx.Transaction.Nonce()),
-		Price:        (*hexutil.Big)(tx.Transaction.GasPrice()),
 		GasLimit:     hexutil.Uint64(tx.Transaction.Gas()),
 		Recipient:    tx.Transaction.To(),
 		Amount:       (*hexutil.Big)(tx.Transaction.Value()),
@@ -86,5 +116,22 @@ func (tx *Transaction) MarshalJSON() ([]byte, error) {
 		S:            (*hexutil.Big)(s),
 		Hash:         &h,
 	}
+	switch tx.Transaction.Type() {
+	case types.LegacyTxType:
+		txmar.Price = (*hexutil.Big)(tx.Transaction.GasPrice())
+	case types.AccessListTxType:
+		accessList := tx.Transaction.AccessList()
+		txmar.ChainID = (*hexutil.Big)(tx.Transaction.ChainId())
+		txmar.Price = (*hexutil.Big)(tx.Transaction.GasPrice())
+		txmar.AccessList = &accessList
+	case types.DynamicFeeTxType:
+		accessList := tx.Transaction.AccessList()
+		txmar.ChainID = (*hexutil.Big)(tx.Transaction.ChainId())
+		txmar.MaxPriorityFeePerGas = (*hexutil.Big)(tx.Transaction.GasTipCap())
+		txmar.MaxFeePerGas = (*hexutil.Big)(tx.Transaction.GasFeeCap())
+		txmar.AccessList = &accessList
+	default:
+		return nil, types.ErrTxTypeNotSupported
+	}
 	return json.Marshal(txmar)
 }
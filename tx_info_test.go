@@ -0,0 +1,90 @@
+package ethutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestTransactionJSONRoundTrip(t *testing.T) {
+	key, err := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainID := int64(1)
+	signer := types.NewLondonSigner(big.NewInt(chainID))
+	to := "0x00000000000000000000000000000000000A11cE"
+	value := big.NewInt(1234567890123456789)
+	data := []byte{0xa9, 0x05, 0x9c, 0xbb}
+	accessList := types.AccessList{{
+		Address:     common.HexToAddress(to),
+		StorageKeys: []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")},
+	}}
+	blockNumber := "0xbc614e"
+	blockHash := common.HexToHash("0x1ab2")
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	txs := []struct {
+		name string
+		tx   *types.Transaction
+	}{
+		{"legacy", BuildExactTx(7, to, value, 21000, 1.5, data)},
+		{"legacy contract creation", BuildContractCreationTx(7, value, 100000, 1.5, data)},
+		{"access list", BuildExactAccessListTx(chainID, 7, to, value, 30000, 1.5, data, accessList)},
+		{"access list contract creation", BuildAccessListContractCreationTx(chainID, 7, value, 100000, 1.5, data, accessList)},
+		{"dynamic fee", BuildExactDynamicFeeTx(chainID, 7, to, value, 21000, 1, 100.5, data)},
+		{"dynamic fee contract creation", BuildDynamicFeeContractCreationTx(chainID, 7, value, 100000, 1, 100.5, data)},
+	}
+	for _, c := range txs {
+		signedTx, err := types.SignTx(c.tx, signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		variants := []struct {
+			name string
+			tx   *Transaction
+		}{
+			{"unsigned", &Transaction{Transaction: c.tx}},
+			{"signed", &Transaction{Transaction: signedTx}},
+			{"signed and mined", &Transaction{
+				Transaction: signedTx,
+				Extra:       TxExtraInfo{BlockNumber: &blockNumber, BlockHash: &blockHash, From: &from},
+			}},
+		}
+		for _, v := range variants {
+			name := c.name + " " + v.name
+			encoded, err := json.Marshal(v.tx)
+			if err != nil {
+				t.Fatalf("%s: Marshal failed: %s", name, err)
+			}
+			decoded := &Transaction{}
+			if err := json.Unmarshal(encoded, decoded); err != nil {
+				t.Fatalf("%s: Unmarshal failed: %s\n%s", name, err, encoded)
+			}
+			if decoded.Type() != v.tx.Type() {
+				t.Errorf("%s: type %d, want %d", name, decoded.Type(), v.tx.Type())
+			}
+			if decoded.Hash() != v.tx.Hash() {
+				t.Errorf("%s: hash %s, want %s", name, decoded.Hash().Hex(), v.tx.Hash().Hex())
+			}
+			if signer.Hash(decoded.Transaction) != signer.Hash(v.tx.Transaction) {
+				t.Errorf("%s: signing hash changed", name)
+			}
+			if (decoded.To() == nil) != (v.tx.To() == nil) {
+				t.Errorf("%s: recipient %v, want %v", name, decoded.To(), v.tx.To())
+			}
+			reencoded, err := json.Marshal(decoded)
+			if err != nil {
+				t.Fatalf("%s: Marshal of the decoded tx failed: %s", name, err)
+			}
+			if !bytes.Equal(encoded, reencoded) {
+				t.Errorf("%s: JSON changed after the round trip\n%s\n%s", name, encoded, reencoded)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package ethutils
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TxStatus is the mining status of a tx as observed by a reader or a
+// monitor. The zero value is TxStatusUnknown.
+type TxStatus int
+
+const (
+	// TxStatusUnknown means the status hasn't been read yet
+	TxStatusUnknown TxStatus = iota
+	// TxStatusError means the status couldn't be determined because
+	// reading from the nodes failed
+	TxStatusError
+	// TxStatusNotFound means no node knows about the tx
+	TxStatusNotFound
+	// TxStatusPending means the tx is known by the nodes but not mined yet
+	TxStatusPending
+	// TxStatusDone means the tx is mined and executed successfully
+	TxStatusDone
+	// TxStatusReverted means the tx is mined but its execution reverted
+	TxStatusReverted
+	// TxStatusLost means the tx was never seen by the nodes for too long
+	// and is considered dropped
+	TxStatusLost
+)
+
+var txStatusNames = map[TxStatus]string{
+	TxStatusUnknown:  "unknown",
+	TxStatusError:    "error",
+	TxStatusNotFound: "notfound",
+	TxStatusPending:  "pending",
+	TxStatusDone:     "done",
+	TxStatusReverted: "reverted",
+	TxStatusLost:     "lost",
+}
+
+// ParseTxStatus converts the string form of a status, as returned by
+// TxStatus.String, back to a TxStatus
+func ParseTxStatus(s string) (TxStatus, error) {
+	for status, name := range txStatusNames {
+		if name == s {
+			return status, nil
+		}
+	}
+	return TxStatusUnknown, fmt.Errorf("unknown tx status: %s", s)
+}
+
+func (self TxStatus) String() string {
+	name, found := txStatusNames[self]
+	if !found {
+		return fmt.Sprintf("unknown(%d)", int(self))
+	}
+	return name
+}
+
+// IsFinal returns true if the status will not change anymore
+func (self TxStatus) IsFinal() bool {
+	return self == TxStatusDone || self == TxStatusReverted || self == TxStatusLost
+}
+
+// IsMined returns true if the tx is included in a block regardless of
+// its execution result
+func (self TxStatus) IsMined() bool {
+	return self == TxStatusDone || self == TxStatusReverted
+}
+
+// IsSuccess returns true if the tx is mined and executed successfully
+func (self TxStatus) IsSuccess() bool {
+	return self == TxStatusDone
+}
+
+func (self TxStatus) MarshalJSON() ([]byte, error) {
+	if _, found := txStatusNames[self]; !found {
+		return nil, fmt.Errorf("unknown tx status: %d", int(self))
+	}
+	return json.Marshal(self.String())
+}
+
+func (self *TxStatus) UnmarshalJSON(msg []byte) error {
+	var s string
+	if err := json.Unmarshal(msg, &s); err != nil {
+		return err
+	}
+	status, err := ParseTxStatus(s)
+	if err != nil {
+		return err
+	}
+	*self = status
+	return nil
+}
@@ -0,0 +1,129 @@
+package ethutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestTxStatusJSON(t *testing.T) {
+	var zero TxStatus
+	if zero != TxStatusUnknown {
+		t.Errorf("zero value is %s, want unknown", zero)
+	}
+	for status, name := range txStatusNames {
+		encoded, err := json.Marshal(status)
+		if err != nil {
+			t.Fatalf("Marshal(%s) failed: %s", name, err)
+		}
+		if string(encoded) != `"`+name+`"` {
+			t.Errorf("Marshal(%s) = %s", name, encoded)
+		}
+		var decoded TxStatus
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Unmarshal(%s) failed: %s", encoded, err)
+		}
+		if decoded != status {
+			t.Errorf("Unmarshal(%s) = %s, want %s", encoded, decoded, status)
+		}
+	}
+	if _, err := json.Marshal(TxStatus(100)); err == nil {
+		t.Errorf("Marshal of an undefined status succeeded")
+	}
+	for _, invalid := range []string{`"mined"`, `3`, `""`} {
+		var decoded TxStatus
+		if err := json.Unmarshal([]byte(invalid), &decoded); err == nil {
+			t.Errorf("Unmarshal(%s) = %s, want an error", invalid, decoded)
+		}
+	}
+}
+
+func TestTxInfoJSONRoundTrip(t *testing.T) {
+	key, err := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := "0x00000000000000000000000000000000000A11cE"
+	tx, err := types.SignTx(
+		BuildExactDynamicFeeTx(1, 7, to, big.NewInt(1000), 50000, 1, 100, []byte{0x01}),
+		types.NewLondonSigner(big.NewInt(1)), key,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	blockNumber := "0xbc614e"
+	header := &types.Header{
+		Number:     big.NewInt(12345678),
+		Difficulty: big.NewInt(0),
+		GasLimit:   30000000,
+		GasUsed:    21000,
+		Time:       1650000000,
+		BaseFee:    big.NewInt(20000000000),
+	}
+	blockHash := header.Hash()
+	receipt := &types.Receipt{
+		Type:              types.DynamicFeeTxType,
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+		Logs: []*types.Log{{
+			Address: common.HexToAddress(to),
+			Topics:  []common.Hash{common.HexToHash("0xddf252ad")},
+			Data:    []byte{0x02},
+		}},
+		TxHash:      tx.Hash(),
+		GasUsed:     21000,
+		BlockHash:   blockHash,
+		BlockNumber: header.Number,
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	infos := []TxInfo{
+		{},
+		{Status: TxStatusError},
+		{Status: TxStatusPending, Tx: &Transaction{Transaction: tx, Extra: TxExtraInfo{From: &from}}},
+		{
+			Status: TxStatusDone,
+			Tx: &Transaction{
+				Transaction: tx,
+				Extra:       TxExtraInfo{BlockNumber: &blockNumber, BlockHash: &blockHash, From: &from},
+			},
+			InternalTxs: []InternalTx{{From: to, To: from.Hex(), Value: "1000", Type: "CALL", Depth: 1}},
+			Receipt:     receipt,
+			BlockHeader: header,
+		},
+	}
+	for _, info := range infos {
+		encoded, err := json.Marshal(info)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %s", info.Status, err)
+		}
+		var decoded TxInfo
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("%s: Unmarshal failed: %s\n%s", info.Status, err, encoded)
+		}
+		if decoded.Status != info.Status {
+			t.Errorf("%s: decoded status %s", info.Status, decoded.Status)
+		}
+		if info.Tx != nil && decoded.Tx.Hash() != info.Tx.Hash() {
+			t.Errorf("%s: decoded tx %s, want %s", info.Status, decoded.Tx.Hash().Hex(), info.Tx.Hash().Hex())
+		}
+		if info.BlockHeader != nil && decoded.BlockHeader.Hash() != info.BlockHeader.Hash() {
+			t.Errorf("%s: decoded header %s, want %s", info.Status, decoded.BlockHeader.Hash().Hex(), blockHash.Hex())
+		}
+		reencoded, err := json.Marshal(decoded)
+		if err != nil {
+			t.Fatalf("%s: Marshal of the decoded info failed: %s", info.Status, err)
+		}
+		if !bytes.Equal(encoded, reencoded) {
+			t.Errorf("%s: JSON changed after the round trip\n%s\n%s", info.Status, encoded, reencoded)
+		}
+	}
+	if info := (TxInfo{Tx: infos[3].Tx, Receipt: receipt, BlockHeader: header}); info.GasCost().Cmp(big.NewInt(21000*21000000000)) != 0 {
+		t.Errorf("GasCost() = %s", info.GasCost())
+	}
+}
@@ -122,6 +122,21 @@ func (self *TxAnalyzer) AnalyzeMethodCall(abi *abi.ABI, data []byte) (method str
 	return method, params, gnosisResult, nil
 }
 
+// topicAsString decodes an indexed argument. Strings, bytes, arrays,
+// slices and tuples are only kept as their hash in the topic so the hash
+// is returned.
+func (self *TxAnalyzer) topicAsString(arg abi.Argument, topic common.Hash) string {
+	switch arg.Type.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy, abi.FunctionTy:
+		return topic.Hex()
+	}
+	value := map[string]interface{}{}
+	if err := abi.ParseTopicsIntoMap(value, abi.Arguments{arg}, []common.Hash{topic}); err != nil {
+		return topic.Hex()
+	}
+	return self.ParamAsString(arg.Type, value[arg.Name])
+}
+
 func (self *TxAnalyzer) AnalyzeLog(abi *abi.ABI, l *types.Log) (LogResult, error) {
 	logResult := LogResult{
 		Name:   "",
@@ -139,7 +154,7 @@ func (self *TxAnalyzer) AnalyzeLog(abi *abi.ABI, l *types.Log) (LogResult, error
 	for j, topic := range l.Topics[1:] {
 		logResult.Topics = append(logResult.Topics, TopicResult{
 			Name:  iArgs[j].Name,
-			Value: topic.Hex(),
+			Value: self.topicAsString(iArgs[j], topic),
 		})
 	}
 
@@ -174,6 +189,13 @@ func (self *TxAnalyzer) analyzeContractTx(txinfo ethutils.TxInfo, abi *abi.ABI,
 	logs := txinfo.Receipt.Logs
 	for _, l := range logs {
 		logResult, err := self.AnalyzeLog(abi, l)
+		if err != nil && len(l.Topics) > 0 {
+			// the log might be emitted by another contract such as a
+			// token, try the builtin ABIs
+			if builtin, _, berr := ethutils.FindBuiltinABIByEvent(*l); berr == nil {
+				logResult, err = self.AnalyzeLog(builtin, l)
+			}
+		}
 		if err != nil {
 			result.Error += fmt.Sprintf("%s", err)
 		}
@@ -211,7 +233,7 @@ func (self *TxAnalyzer) gnosisMultisigInitData(inputs []abi.Argument, params []i
 		Name:    self.addrdb.GetName(contract.Hex()),
 	}
 	data := params[2].([]byte)
-	abi, err := self.reader.GetABI(contract.Hex())
+	abi, err := self.getABI(contract.Hex(), data)
 	if err != nil {
 		result.Error = fmt.Sprintf("Cannot get abi of the contract: %s", err)
 		return result
@@ -246,8 +268,8 @@ func (self *TxAnalyzer) AnalyzeOffline(txinfo *ethutils.TxInfo, abi *abi.ABI, is
 	// fmt.Printf("tx hash: %s\n", tx)
 	result.Hash = txinfo.Tx.Hash().Hex()
 	// fmt.Printf("mining status: %s\n", txinfo.Status)
-	result.Status = txinfo.Status
-	if txinfo.Status == "done" || txinfo.Status == "reverted" {
+	result.Status = txinfo.Status.String()
+	if txinfo.Status.IsMined() {
 		self.setBasicTxInfo(*txinfo, result)
 		if !isContract {
 			// fmt.Printf("tx type: normal\n")
@@ -262,6 +284,22 @@ func (self *TxAnalyzer) AnalyzeOffline(txinfo *ethutils.TxInfo, abi *abi.ABI, is
 	return result
 }
 
+// getABI returns the ABI of the contract from the block explorer. If the
+// explorer doesn't have it, it falls back to the builtin ABI that has
+// the method of the call data so standard contracts can be analyzed
+// offline.
+func (self *TxAnalyzer) getABI(address string, data []byte) (*abi.ABI, error) {
+	result, err := self.reader.GetABI(address)
+	if err == nil {
+		return result, nil
+	}
+	builtin, _, berr := ethutils.FindBuiltinABIByMethod(data)
+	if berr != nil {
+		return nil, fmt.Errorf("%s, %w", err, berr)
+	}
+	return builtin, nil
+}
+
 // print all info on the tx
 func (self *TxAnalyzer) Analyze(tx string) *TxResult {
 	txinfo, err := self.reader.TxInfoFromHash(tx)
@@ -280,7 +318,7 @@ func (self *TxAnalyzer) Analyze(tx string) *TxResult {
 	isContract := len(code) > 0
 
 	if isContract {
-		abi, err := self.reader.GetABI(txinfo.Tx.To().Hex())
+		abi, err := self.getABI(txinfo.Tx.To().Hex(), txinfo.Tx.Data())
 		if err != nil {
 			return &TxResult{
 				Error: fmt.Sprintf("Cannot get abi of the contract: %s", err),
@@ -296,6 +334,12 @@ func (self *TxAnalyzer) SetAddressDatabase(db AddressDatabase) {
 	self.addrdb = db
 }
 
+// UseENSNames makes the analyzer name the addresses unknown to its
+// current address database with their primary ENS names
+func (self *TxAnalyzer) UseENSNames() {
+	self.addrdb = NewENSAddressDatabase(self.reader, self.addrdb)
+}
+
 func NewAnalyzer() *TxAnalyzer {
 	return &TxAnalyzer{
 		reader.NewEthReader(),
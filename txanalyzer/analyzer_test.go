@@ -0,0 +1,51 @@
+package txanalyzer
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type namesDatabase map[string]string
+
+func (self namesDatabase) GetName(addr string) string {
+	return self[addr]
+}
+
+func TestTopicAsString(t *testing.T) {
+	analyzer := &TxAnalyzer{addrdb: namesDatabase{
+		"0x00000000000000000000000000000000000A11cE": "alice",
+	}}
+	argument := func(typ string) abi.Argument {
+		t, err := abi.NewType(typ, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		return abi.Argument{Name: "arg", Type: t, Indexed: true}
+	}
+	hash := "0xb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf6"
+	cases := []struct {
+		typ   string
+		topic string
+		want  string
+	}{
+		{"address", "0x00000000000000000000000000000000000000000000000000000000000a11ce", "0x00000000000000000000000000000000000A11cE - (alice)"},
+		{"uint256", "0x00000000000000000000000000000000000000000000000000000000000003e8", "1000 (0x3e8)"},
+		{"bool", "0x0000000000000000000000000000000000000000000000000000000000000001", "true"},
+		{"bytes32", hash, hash},
+		// dynamic types are only kept as their hash
+		{"string", hash, hash},
+		{"bytes", hash, hash},
+		{"uint256[]", hash, hash},
+		{"address[2]", hash, hash},
+		// a topic that doesn't decode as the type is shown as is
+		{"bool", hash, hash},
+	}
+	for _, c := range cases {
+		got := analyzer.topicAsString(argument(c.typ), common.HexToHash(c.topic))
+		if got != c.want {
+			t.Errorf("%s %s: got %q, want %q", c.typ, c.topic, got, c.want)
+		}
+	}
+}
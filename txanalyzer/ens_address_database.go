@@ -0,0 +1,56 @@
+package txanalyzer
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tranvictor/ethutils/reader"
+)
+
+// ENSAddressDatabase names addresses with a fallback database first and
+// with their primary ENS name (addr.reverse record) when the fallback
+// doesn't know them. Names and addresses without a primary name are
+// cached, lookups failing for other reasons (eg. network errors) are
+// retried on the next call.
+type ENSAddressDatabase struct {
+	reader   *reader.EthReader
+	fallback AddressDatabase
+
+	mu    sync.Mutex
+	cache map[common.Address]string
+}
+
+func NewENSAddressDatabase(r *reader.EthReader, fallback AddressDatabase) *ENSAddressDatabase {
+	return &ENSAddressDatabase{
+		reader:   r,
+		fallback: fallback,
+		cache:    map[common.Address]string{},
+	}
+}
+
+func (self *ENSAddressDatabase) GetName(addr string) string {
+	if self.fallback != nil {
+		if name := self.fallback.GetName(addr); name != "unknown" {
+			return name
+		}
+	}
+	address := common.HexToAddress(addr)
+	self.mu.Lock()
+	name, found := self.cache[address]
+	self.mu.Unlock()
+	if found {
+		return name
+	}
+	name, err := self.reader.LookupAddress(address.Hex())
+	if err != nil {
+		if !errors.Is(err, reader.ErrNoPrimaryName) {
+			return "unknown"
+		}
+		name = "unknown"
+	}
+	self.mu.Lock()
+	self.cache[address] = name
+	self.mu.Unlock()
+	return name
+}
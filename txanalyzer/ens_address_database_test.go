@@ -0,0 +1,68 @@
+package txanalyzer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/tranvictor/ethutils/reader"
+)
+
+func TestENSAddressDatabaseCaching(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var request struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		calls++
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+		if failing {
+			response["error"] = map[string]interface{}{"code": -32000, "message": "header not found"}
+		} else {
+			// the registry has no resolver for the reverse record
+			response["result"] = "0x0000000000000000000000000000000000000000000000000000000000000000"
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+	db := NewENSAddressDatabase(
+		reader.NewEthReaderGeneric(map[string]string{"node": server.URL}, nil), nil)
+	addr := "0x00000000000000000000000000000000000A11cE"
+	lookup := func() int {
+		mu.Lock()
+		before := calls
+		mu.Unlock()
+		if name := db.GetName(addr); name != "unknown" {
+			t.Fatalf("GetName() = %s, want unknown", name)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return calls - before
+	}
+
+	for i := 0; i < 2; i++ {
+		if n := lookup(); n == 0 {
+			t.Fatalf("lookup %d after a failure didn't query the node", i)
+		}
+	}
+	mu.Lock()
+	failing = false
+	mu.Unlock()
+	if n := lookup(); n == 0 {
+		t.Fatalf("lookup after recovery didn't query the node")
+	}
+	if n := lookup(); n != 0 {
+		t.Fatalf("address without primary name wasn't cached, %d calls", n)
+	}
+}
@@ -0,0 +1,124 @@
+package ethutils
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParseUnits converts a decimal string to a big int with specific decimal
+// without going through float64 so no precision is lost.
+// It returns an error if the amount has more fractional digits than
+// decimal. A negative decimal divides amount by 10^-decimal, the
+// inverse of FormatUnits, and returns an error if the division isn't
+// exact.
+// Example:
+// - ParseUnits("1", 4) = 10000
+// - ParseUnits("1.234", 4) = 12340
+// - ParseUnits("0.00001", 4) returns an error
+// - ParseUnits("1100", -2) = 11
+// - ParseUnits("1150", -2) returns an error
+func ParseUnits(amount string, decimal int64) (*big.Int, error) {
+	s := strings.TrimSpace(amount)
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 2 {
+		return nil, fmt.Errorf("invalid amount %q: more than one decimal point", amount)
+	}
+	whole := parts[0]
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = parts[1]
+	}
+	if whole == "" && fraction == "" {
+		return nil, fmt.Errorf("invalid amount %q: no digits", amount)
+	}
+	for _, c := range whole + fraction {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("invalid amount %q: unexpected character %q", amount, c)
+		}
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	scale := decimal
+	if scale < 0 {
+		scale = 0
+	}
+	if int64(len(fraction)) > scale {
+		return nil, fmt.Errorf("invalid amount %q: more than %d fractional digits", amount, scale)
+	}
+	digits := whole + fraction + strings.Repeat("0", int(scale)-len(fraction))
+	result, ok := big.NewInt(0).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	if decimal < 0 {
+		divisor := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(-decimal), nil)
+		remainder := big.NewInt(0)
+		result.QuoRem(result, divisor, remainder)
+		if remainder.Sign() != 0 {
+			return nil, fmt.Errorf("invalid amount %q: not a multiple of 10^%d", amount, -decimal)
+		}
+	}
+	if negative {
+		result.Neg(result)
+	}
+	return result, nil
+}
+
+// FormatUnits converts a big int to its exact decimal string according
+// to its number of decimal digits. Trailing zeros of the fractional
+// part are removed. A negative decimal multiplies amount by
+// 10^-decimal.
+// Example:
+// - FormatUnits(1100, 3) = "1.1"
+// - FormatUnits(1100, 2) = "11"
+// - FormatUnits(1100, 5) = "0.011"
+// - FormatUnits(11, -2) = "1100"
+func FormatUnits(amount *big.Int, decimal int64) string {
+	if amount == nil || amount.Sign() == 0 {
+		return "0"
+	}
+	digits := big.NewInt(0).Abs(amount).String()
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+	if decimal <= 0 {
+		return sign + digits + strings.Repeat("0", int(-decimal))
+	}
+	if int64(len(digits)) <= decimal {
+		digits = strings.Repeat("0", int(decimal)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(decimal)
+	whole, fraction := digits[:point], strings.TrimRight(digits[point:], "0")
+	if fraction == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + fraction
+}
+
+// ParseEther converts a decimal string of ETH to wei
+func ParseEther(amount string) (*big.Int, error) {
+	return ParseUnits(amount, 18)
+}
+
+// FormatEther converts wei to an exact decimal string of ETH
+func FormatEther(amount *big.Int) string {
+	return FormatUnits(amount, 18)
+}
+
+// ParseGwei converts a decimal string of gwei to wei
+func ParseGwei(amount string) (*big.Int, error) {
+	return ParseUnits(amount, 9)
+}
+
+// FormatGwei converts wei to an exact decimal string of gwei
+func FormatGwei(amount *big.Int) string {
+	return FormatUnits(amount, 9)
+}
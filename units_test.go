@@ -0,0 +1,143 @@
+package ethutils
+
+import (
+	"math/big"
+	"testing"
+)
+
+const maxUint256 = "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+
+func bigFromString(t *testing.T, s string) *big.Int {
+	t.Helper()
+	result, ok := big.NewInt(0).SetString(s, 10)
+	if !ok {
+		t.Fatalf("invalid big int %q", s)
+	}
+	return result
+}
+
+func TestParseUnits(t *testing.T) {
+	cases := []struct {
+		amount  string
+		decimal int64
+		want    string
+		wantErr bool
+	}{
+		{"1", 4, "10000", false},
+		{"1.234", 4, "12340", false},
+		{"1.2340", 4, "12340", false},
+		{"1.23400000", 4, "12340", false},
+		{"0.0001", 4, "1", false},
+		{"0.00001", 4, "", true},
+		{"0.00010", 4, "1", false},
+		{".5", 1, "5", false},
+		{"5.", 1, "50", false},
+		{"0", 18, "0", false},
+		{"-1.5", 18, "-1500000000000000000", false},
+		{"+2", 0, "2", false},
+		{" 3 ", 0, "3", false},
+		{"1.5", 0, "", true},
+		{"1.0", 0, "1", false},
+		{maxUint256, 0, maxUint256, false},
+		{"1100", -2, "11", false},
+		{"-1100.00", -2, "-11", false},
+		{"0", -2, "0", false},
+		{"1150", -2, "", true},
+		{"1", -1, "", true},
+		{"10.5", -1, "", true},
+		{"", 18, "", true},
+		{".", 18, "", true},
+		{"-", 18, "", true},
+		{"1.2.3", 18, "", true},
+		{"1e18", 18, "", true},
+		{"1,5", 18, "", true},
+		{"abc", 18, "", true},
+	}
+	for _, c := range cases {
+		got, err := ParseUnits(c.amount, c.decimal)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("ParseUnits(%q, %d) = %s, want an error", c.amount, c.decimal, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseUnits(%q, %d) failed: %s", c.amount, c.decimal, err)
+			continue
+		}
+		if got.Cmp(bigFromString(t, c.want)) != 0 {
+			t.Errorf("ParseUnits(%q, %d) = %s, want %s", c.amount, c.decimal, got, c.want)
+		}
+	}
+}
+
+func TestFormatUnits(t *testing.T) {
+	cases := []struct {
+		amount  string
+		decimal int64
+		want    string
+	}{
+		{"1100", 3, "1.1"},
+		{"1100", 2, "11"},
+		{"1100", 5, "0.011"},
+		{"1100", 4, "0.11"},
+		{"1", 18, "0.000000000000000001"},
+		{"-1", 18, "-0.000000000000000001"},
+		{"1000000000000000000", 18, "1"},
+		{"1500000000000000000", 18, "1.5"},
+		{"-1500000000000000000", 18, "-1.5"},
+		{"0", 18, "0"},
+		{"12345", 0, "12345"},
+		{"12345", -2, "1234500"},
+		{"-12345", -2, "-1234500"},
+		{"0", -2, "0"},
+		{maxUint256, 18, "115792089237316195423570985008687907853269984665640564039457.584007913129639935"},
+	}
+	for _, c := range cases {
+		got := FormatUnits(bigFromString(t, c.amount), c.decimal)
+		if got != c.want {
+			t.Errorf("FormatUnits(%s, %d) = %q, want %q", c.amount, c.decimal, got, c.want)
+		}
+	}
+	if got := FormatUnits(nil, 18); got != "0" {
+		t.Errorf("FormatUnits(nil, 18) = %q, want \"0\"", got)
+	}
+}
+
+func TestUnitsRoundTrip(t *testing.T) {
+	amounts := []string{"0", "1", "-1", "9", "10", "100", "123456789", "-987654321000", "1000000000000000000", maxUint256}
+	for _, decimal := range []int64{-3, 0, 1, 6, 9, 18, 30} {
+		for _, a := range amounts {
+			amount := bigFromString(t, a)
+			s := FormatUnits(amount, decimal)
+			back, err := ParseUnits(s, decimal)
+			if err != nil {
+				t.Errorf("ParseUnits(FormatUnits(%s, %d) = %q) failed: %s", a, decimal, s, err)
+				continue
+			}
+			if back.Cmp(amount) != 0 {
+				t.Errorf("round trip of %s with %d decimals gave %s via %q", a, decimal, back, s)
+			}
+		}
+	}
+}
+
+func TestEtherAndGwei(t *testing.T) {
+	wei, err := ParseEther("1.000000000000000001")
+	if err != nil || wei.String() != "1000000000000000001" {
+		t.Errorf("ParseEther = %s, %v", wei, err)
+	}
+	if got := FormatEther(wei); got != "1.000000000000000001" {
+		t.Errorf("FormatEther = %q", got)
+	}
+	if _, err := ParseEther("0.0000000000000000001"); err == nil {
+		t.Errorf("ParseEther accepted more than 18 fractional digits")
+	}
+	gwei, err := ParseGwei("2.5")
+	if err != nil || gwei.String() != "2500000000" {
+		t.Errorf("ParseGwei = %s, %v", gwei, err)
+	}
+	if got := FormatGwei(gwei); got != "2.5" {
+		t.Errorf("FormatGwei = %q", got)
+	}
+}
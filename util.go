@@ -46,6 +46,10 @@ func PackERC20Data(function string, params ...interface{}) ([]byte, error) {
 	return GetERC20ABI().Pack(function, params...)
 }
 
+// FloatToInt rounds amount to the nearest int64. It panics if the
+// result doesn't fit in an int64 or amount is NaN.
+//
+// Deprecated: use ParseFloatInt which returns an error instead.
 func FloatToInt(amount float64) int64 {
 	s := fmt.Sprintf("%.0f", amount)
 	if i, err := strconv.Atoi(s); err == nil {
@@ -55,16 +59,18 @@ func FloatToInt(amount float64) int64 {
 	}
 }
 
-// FloatToBigInt converts a float to a big int with specific decimal
+// FloatToBigInt converts a float to a big int with specific decimal.
+// NaN and infinite amounts are converted to 0, use ParseFloatAmount to
+// reject them.
 // Example:
 // - FloatToBigInt(1, 4) = 10000
 // - FloatToBigInt(1.234, 4) = 12340
 func FloatToBigInt(amount float64, decimal int64) *big.Int {
 	// 6 is our smallest precision
 	if decimal < 6 {
-		return big.NewInt(FloatToInt(amount * math.Pow10(int(decimal))))
+		return roundFloat(amount * math.Pow10(int(decimal)))
 	}
-	result := big.NewInt(FloatToInt(amount * math.Pow10(6)))
+	result := roundFloat(amount * math.Pow10(6))
 	return result.Mul(result, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(decimal-6), nil))
 }
 
@@ -109,10 +115,14 @@ func EthToWei(n float64) *big.Int {
 	return FloatToBigInt(n, 18)
 }
 
+// HexToHash doesn't validate its input, use ParseHash for user input
 func HexToHash(hex string) common.Hash {
 	return common.HexToHash(hex)
 }
 
+// HexToBig panics if hex is not a valid hex quantity.
+//
+// Deprecated: use ParseHexBig which returns an error instead.
 func HexToBig(hex string) *big.Int {
 	result, err := hexutil.DecodeBig(hex)
 	if err != nil {
@@ -121,6 +131,8 @@ func HexToBig(hex string) *big.Int {
 	return result
 }
 
+// HexToAddress doesn't validate its input and returns garbage for
+// invalid addresses, use ParseAddress for user input
 func HexToAddress(hex string) common.Address {
 	return common.HexToAddress(hex)
 }